@@ -3,25 +3,35 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
-	"time"
 
 	comunicacion "MV1/proto/grpc-server/proto"
+	"MV1/reloj"
 
 	"google.golang.org/grpc"
 )
 
 var jugador *comunicacion.Jugador
-var vectorClock = map[string]int32{
-	"Player1":     0,
-	"Matchmaker":  0,
-	"GameServer1": 0,
-}
+var vectorClock *reloj.Proceso
 
 func main() {
+	eventos := flag.String("eventos", "eventos_player1.jsonl", "registro causal de eventos (vacío para no registrarlos)")
+	flag.Parse()
+
+	registro, err := reloj.AbrirRegistro("Player1", *eventos)
+	if err != nil {
+		log.Fatalf("No se pudo abrir el registro de eventos: %v", err)
+	}
+	defer registro.Cerrar()
+	vectorClock = reloj.NuevoProceso("Player1", registro)
+	// Cada línea del log lleva la marca HLC del último evento del proceso
+	log.SetOutput(vectorClock.Hibrido().Escritor(os.Stderr))
+
 	// Captura nombre del jugador
 	fmt.Print("Ingrese el nombre del jugador 1: ")
 	reader := bufio.NewReader(os.Stdin)
@@ -31,15 +41,23 @@ func main() {
 		log.Fatal("El nombre no puede estar vacío.")
 	}
 
+	// Modo de juego preferido (Casual por defecto)
+	fmt.Print("Ingrese el modo de juego (Casual / Ranked / Duos / Equipos / FFA) [Casual]: ")
+	modo, _ := reader.ReadString('\n')
+	modo = strings.TrimSpace(modo)
+	if modo == "" {
+		modo = "Casual"
+	}
+
 	jugador = &comunicacion.Jugador{
 		Id:                 1,
 		Name:               nombre,
-		GameModePreference: "Casual",
+		GameModePreference: modo,
 		Status:             "IDLE",
 	}
 
-	// Conexión gRPC con el Matchmaker
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(5*time.Second))
+	// Conexión gRPC con el cluster de matchmakers
+	conn, err := conectarMatchmaker()
 	if err != nil {
 		log.Fatalf("No se pudo conectar al Matchmaker: %v", err)
 	}
@@ -51,8 +69,12 @@ func main() {
 	for {
 		fmt.Println("\n--- Menú Jugador ---")
 		fmt.Println("1. Unirse a cola de emparejamiento")
-		fmt.Println("2. Consultar estado")
-		fmt.Println("3. Salir")
+		fmt.Println("2. Salir de la cola (cancelar búsqueda)")
+		fmt.Println("3. Consultar estado")
+		fmt.Println("4. Crear grupo")
+		fmt.Println("5. Unirse a un grupo")
+		fmt.Println("6. Salir del grupo")
+		fmt.Println("7. Salir")
 		fmt.Print("Seleccione una opción: ")
 		opcion, _ := reader.ReadString('\n')
 		opcion = strings.TrimSpace(opcion)
@@ -61,8 +83,25 @@ func main() {
 		case "1":
 			queuePlayer(client)
 		case "2":
-			getPlayerStatus(client)
+			leaveQueue(client)
 		case "3":
+			getPlayerStatus(client, reader)
+		case "4":
+			operacionGrupo("CreateParty", client.CreateParty, 0)
+		case "5":
+			fmt.Print("Ingrese el ID del grupo: ")
+			entrada, _ := reader.ReadString('\n')
+			partyID, err := strconv.Atoi(strings.TrimSpace(entrada))
+			if err != nil {
+				fmt.Println("ID de grupo inválido.")
+				continue
+			}
+			operacionGrupo("JoinParty", client.JoinParty, int32(partyID))
+		case "6":
+			operacionGrupo("LeaveParty", client.LeaveParty, 0)
+		case "7":
+			// Evita dejar una entrada fantasma en la cola del Matchmaker
+			leaveQueue(client)
 			fmt.Println("Saliendo del juego.")
 			return
 		default:
@@ -72,8 +111,7 @@ func main() {
 }
 
 func queuePlayer(client comunicacion.ComunicacionServiceClient) {
-	vectorClock["Player1"]++
-	vc := &comunicacion.VectorClock{Clocks: vectorClock}
+	vc := vectorClock.EnviarMensaje("QueuePlayer")
 
 	req := &comunicacion.PlayerInfoRequest{
 		PlayerId:           jugador.Id,
@@ -81,7 +119,7 @@ func queuePlayer(client comunicacion.ComunicacionServiceClient) {
 		VectorClock:        vc,
 	}
 
-	log.Printf("[Player1] Enviando QueuePlayer con reloj: %+v", vectorClock)
+	log.Printf("[Player1] Enviando QueuePlayer con reloj: %+v", req.VectorClock.Clocks)
 	res, err := client.QueuePlayer(context.Background(), req)
 	if err != nil {
 		log.Println("Error al hacer QueuePlayer:", err)
@@ -89,12 +127,30 @@ func queuePlayer(client comunicacion.ComunicacionServiceClient) {
 	}
 
 	fmt.Println("Respuesta del servidor:", res.Message)
-	mergeVectorClock(res.VectorClock)
+	vectorClock.RecibirMensaje("Respuesta de QueuePlayer", res.VectorClock)
+	log.Printf("[Player1] Recibido reloj: %+v", res.VectorClock.Clocks)
+}
+
+func leaveQueue(client comunicacion.ComunicacionServiceClient) {
+	req := &comunicacion.LeaveQueueRequest{
+		PlayerId:    jugador.Id,
+		VectorClock: vectorClock.EnviarMensaje("LeaveQueue"),
+	}
+
+	log.Printf("[Player1] Enviando LeaveQueue con reloj: %+v", req.VectorClock.Clocks)
+	res, err := client.LeaveQueue(context.Background(), req)
+	if err != nil {
+		log.Println("Error al salir de la cola:", err)
+		return
+	}
+
+	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
+	vectorClock.RecibirMensaje("Respuesta de LeaveQueue", res.VectorClock)
 	log.Printf("[Player1] Recibido reloj: %+v", res.VectorClock.Clocks)
 }
 
-func getPlayerStatus(client comunicacion.ComunicacionServiceClient) {
-	vc := &comunicacion.VectorClock{Clocks: vectorClock}
+func getPlayerStatus(client comunicacion.ComunicacionServiceClient, reader *bufio.Reader) {
+	vc := vectorClock.EnviarMensaje("GetPlayerStatus")
 	req := &comunicacion.PlayerStatusRequest{
 		PlayerId:    jugador.Id,
 		VectorClock: vc,
@@ -108,15 +164,93 @@ func getPlayerStatus(client comunicacion.ComunicacionServiceClient) {
 
 	fmt.Printf("Estado actual: %s\n", res.Status)
 	fmt.Printf("Match ID: %d, Servidor: %s\n", res.MatchId, res.MatchServerAddress)
-	mergeVectorClock(res.VectorClock)
+	if m := res.Match; m != nil {
+		fmt.Printf("Partida %d (%s) en %s | Estado: %s | Inicio: %s\n", m.MatchId, m.GameMode, m.ServerId, m.Status, m.StartTime)
+		for _, team := range m.Teams {
+			fmt.Printf("  Equipo %d: %v\n", team.TeamId, team.PlayersIds)
+		}
+	}
+	fmt.Printf("Rating: %d\n", res.Rating)
+	if res.PartyId != 0 {
+		fmt.Printf("Grupo: %d\n", res.PartyId)
+	}
+	vectorClock.RecibirMensaje("Respuesta de GetPlayerStatus", res.VectorClock)
 	log.Printf("[Player1] Recibido reloj: %+v", res.VectorClock.Clocks)
+
+	if res.Status == "IN QUEUE" {
+		estimarEspera(client)
+	}
+	if res.Status == "MATCH FOUND" {
+		fmt.Printf("¡Partida encontrada! Tienes %d segundos para aceptar.\n", res.ReadyCheckSecondsLeft)
+		fmt.Print("¿Aceptar la partida? (s/n): ")
+		respuesta, _ := reader.ReadString('\n')
+		respuesta = strings.ToLower(strings.TrimSpace(respuesta))
+		responderReadyCheck(client, res.MatchId, respuesta == "s")
+	}
 }
 
-func mergeVectorClock(remote *comunicacion.VectorClock) {
-	for k, v := range remote.Clocks {
-		localVal, exists := vectorClock[k]
-		if !exists || v > localVal {
-			vectorClock[k] = v
-		}
+// Consulta al Matchmaker el tiempo en cola y la espera estimada
+func estimarEspera(client comunicacion.ComunicacionServiceClient) {
+	req := &comunicacion.WaitTimeRequest{
+		PlayerId:    jugador.Id,
+		GameMode:    jugador.GameModePreference,
+		VectorClock: vectorClock.EnviarMensaje("EstimateWaitTime"),
+	}
+
+	res, err := client.EstimateWaitTime(context.Background(), req)
+	if err != nil {
+		log.Println("Error al estimar la espera:", err)
+		return
+	}
+
+	fmt.Printf("Tiempo en cola: %s | Jugadores adelante: %d\n", res.TimeInQueue, res.PlayersAhead)
+	if res.StatusCode == "SUCCESS" {
+		fmt.Printf("Espera estimada: %ds\n", res.EstimatedWaitSeconds)
+	} else {
+		fmt.Println("Espera estimada: desconocida (aún no hay partidas recientes en este modo)")
+	}
+	vectorClock.RecibirMensaje("Respuesta de EstimateWaitTime", res.VectorClock)
+}
+
+func responderReadyCheck(client comunicacion.ComunicacionServiceClient, matchID int32, aceptar bool) {
+	req := &comunicacion.ReadyCheckRequest{
+		PlayerId:    jugador.Id,
+		MatchId:     matchID,
+		Accept:      aceptar,
+		VectorClock: vectorClock.EnviarMensaje("RespondReadyCheck"),
+	}
+
+	log.Printf("[Player1] Enviando RespondReadyCheck con reloj: %+v", req.VectorClock.Clocks)
+	res, err := client.RespondReadyCheck(context.Background(), req)
+	if err != nil {
+		log.Println("Error al responder la partida:", err)
+		return
 	}
+
+	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
+	vectorClock.RecibirMensaje("Respuesta de RespondReadyCheck", res.VectorClock)
+	log.Printf("[Player1] Recibido reloj: %+v", res.VectorClock.Clocks)
+}
+
+// Ejecuta una operación de grupo (crear, unirse o salir) en el Matchmaker
+func operacionGrupo(nombre string, op func(context.Context, *comunicacion.PartyRequest, ...grpc.CallOption) (*comunicacion.PartyResponse, error), partyID int32) {
+	req := &comunicacion.PartyRequest{
+		PlayerId:    jugador.Id,
+		PartyId:     partyID,
+		VectorClock: vectorClock.EnviarMensaje(nombre),
+	}
+
+	log.Printf("[Player1] Enviando %s con reloj: %+v", nombre, req.VectorClock.Clocks)
+	res, err := op(context.Background(), req)
+	if err != nil {
+		log.Printf("Error al hacer %s: %v", nombre, err)
+		return
+	}
+
+	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
+	if res.PartyId != 0 {
+		fmt.Printf("Grupo %d | Líder: %d | Miembros: %v\n", res.PartyId, res.LeaderId, res.MembersIds)
+	}
+	vectorClock.RecibirMensaje("Respuesta de "+nombre, res.VectorClock)
+	log.Printf("[Player1] Recibido reloj: %+v", res.VectorClock.Clocks)
 }
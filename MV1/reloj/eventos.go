@@ -0,0 +1,76 @@
+package reloj
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Tipo de un evento del registro causal.
+type Tipo string
+
+const (
+	Local     Tipo = "LOCAL"
+	Envio     Tipo = "ENVIO"
+	Recepcion Tipo = "RECEPCION"
+)
+
+// Línea del registro causal. MV4/shiviz junta los registros de todos los
+// procesos de una sesión para verlos en ShiViz.
+type Evento struct {
+	Proceso string    `json:"proceso"`
+	Tipo    Tipo      `json:"tipo"`
+	Evento  string    `json:"evento"`
+	Reloj   Reloj     `json:"reloj"`
+	HLC     Marca     `json:"hlc"`
+	Hora    time.Time `json:"hora"`
+}
+
+// Registro causal de un proceso: un archivo con un evento en JSON por línea.
+// Sus métodos no hacen nada sobre un registro nil, así que un proceso sin
+// registro no necesita comprobarlo.
+type Registro struct {
+	mu      sync.Mutex
+	proceso string
+	f       *os.File
+	enc     *json.Encoder
+}
+
+// Abre (o continúa) el registro del proceso en la ruta indicada. Una ruta
+// vacía desactiva el registro.
+func AbrirRegistro(proceso, ruta string) (*Registro, error) {
+	if ruta == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(ruta, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Registro{proceso: proceso, f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Anota un evento con el reloj vectorial y la marca HLC que le asignó el
+// proceso.
+func (r *Registro) Anotar(tipo Tipo, evento string, vc Reloj, marca Marca) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := Evento{Proceso: r.proceso, Tipo: tipo, Evento: evento, Reloj: vc, HLC: marca, Hora: time.Now()}
+	if err := r.enc.Encode(e); err != nil {
+		log.Printf("[%s] No se pudo escribir el registro de eventos: %v", r.proceso, err)
+	}
+}
+
+// Cierra el archivo del registro.
+func (r *Registro) Cerrar() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.f.Close()
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	pb "servidor/proto/grpc-server/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// Retiro ordenado del servidor. Al recibir SIGINT/SIGTERM, o cuando el
+// administrador lo pone en DRAINING, el servidor deja de aceptar partidas,
+// termina la que está jugando, se da de baja en el Matchmaker y se detiene
+var (
+	muDrenaje sync.Mutex
+	drenando  bool
+	jugando   bool           // hay una partida en juego
+	partidas  sync.WaitGroup // partidas en juego
+
+	pedidoDrenaje = make(chan string, 1)
+)
+
+// Registra una partida nueva. Devuelve FailedPrecondition si el servidor se
+// está retirando o si ya está jugando otra partida; el motivo va en los
+// detalles del error para que el Matchmaker lo deje en DRAINING u OCUPADO
+func admitirPartida() error {
+	muDrenaje.Lock()
+	defer muDrenaje.Unlock()
+	if drenando {
+		return rechazarPartida("DRAINING", fmt.Sprintf("%s se está retirando y no acepta partidas", serverID))
+	}
+	if jugando {
+		return rechazarPartida("OCUPADO", fmt.Sprintf("%s ya está jugando una partida", serverID))
+	}
+	jugando = true
+	partidas.Add(1)
+	return nil
+}
+
+// Registra el fin de la partida en juego. Un servidor que simula una caída
+// no la termina: sigue sin aceptar partidas
+func terminarPartida() {
+	muDrenaje.Lock()
+	jugando = false
+	muDrenaje.Unlock()
+	partidas.Done()
+}
+
+// Error FailedPrecondition con el motivo del rechazo en sus detalles
+func rechazarPartida(motivo, mensaje string) error {
+	st, err := grpcstatus.New(codes.FailedPrecondition, mensaje).WithDetails(&errdetails.ErrorInfo{Reason: motivo})
+	if err != nil {
+		return grpcstatus.Error(codes.FailedPrecondition, mensaje)
+	}
+	return st.Err()
+}
+
+func retirandose() bool {
+	muDrenaje.Lock()
+	defer muDrenaje.Unlock()
+	return drenando
+}
+
+// El Matchmaker indicó al renovar el lease que el servidor debe retirarse
+func pedirDrenaje(motivo string) {
+	select {
+	case pedidoDrenaje <- motivo:
+	default:
+	}
+}
+
+// Espera la señal o el pedido de retiro y retira el servidor. Cierra listo
+// al terminar. Una segunda señal detiene el servidor de inmediato
+func esperarRetiro(s *grpc.Server, listo chan<- struct{}) {
+	señales := make(chan os.Signal, 2)
+	signal.Notify(señales, syscall.SIGINT, syscall.SIGTERM)
+
+	var motivo string
+	select {
+	case sig := <-señales:
+		motivo = "señal " + sig.String()
+	case motivo = <-pedidoDrenaje:
+	}
+	go func() {
+		<-señales
+		log.Printf("[%s] Segunda señal: se detiene sin terminar la partida", serverID)
+		os.Exit(1)
+	}()
+
+	drenar(s, motivo)
+	close(listo)
+}
+
+func drenar(s *grpc.Server, motivo string) {
+	log.Printf("[%s] Retirándose (%s): no se aceptan partidas nuevas", serverID, motivo)
+	muDrenaje.Lock()
+	drenando = true
+	muDrenaje.Unlock()
+
+	if estadoActual() != "CAIDO" {
+		cambiarEstado("DRAINING")
+		actualizarEstadoEnMatchmaker("DRAINING")
+	}
+
+	partidas.Wait()
+	// Un servidor caído no puede darse de baja: el Matchmaker ya lo descartó
+	if estadoActual() == "CAIDO" {
+		s.Stop()
+		return
+	}
+	darseDeBaja()
+	s.GracefulStop()
+	log.Printf("[%s] Servidor retirado", serverID)
+}
+
+// Pide al Matchmaker que deje de considerar al servidor. Reintenta mientras
+// el Matchmaker no haya registrado el resultado de la última partida
+func darseDeBaja() {
+	ctx := conIdempotencia(context.Background())
+	for intento := 1; intento <= intentosReporte; intento++ {
+		conn, err := conectarMatchmaker()
+		if err != nil {
+			log.Printf("[%s] No se pudo conectar al Matchmaker: %v", serverID, err)
+			time.Sleep(esperaReporte)
+			continue
+		}
+		client := pb.NewComunicacionServiceClient(conn)
+		res, err := client.DeregisterServer(ctx, &pb.DeregisterRequest{
+			ServerId:    serverID,
+			VectorClock: vectorClock.EnviarMensaje("DeregisterServer"),
+		})
+		if err == nil {
+			vectorClock.RecibirMensaje("Respuesta de DeregisterServer", res.VectorClock)
+		}
+
+		if err == nil && res.StatusCode == "SUCCESS" {
+			log.Printf("[%s] Dado de baja en el Matchmaker: %s", serverID, res.Message)
+			return
+		}
+		if err == nil {
+			log.Printf("[%s] El Matchmaker no dio de baja al servidor (intento %d/%d): %s", serverID, intento, intentosReporte, res.Message)
+			// Cada intento es una baja nueva: la respuesta anterior fue un rechazo
+			ctx = conIdempotencia(context.Background())
+		} else {
+			log.Printf("[%s] Error al darse de baja (intento %d/%d): %v", serverID, intento, intentosReporte, err)
+		}
+		time.Sleep(esperaReporte)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "servidor/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// Metadata con la clave de idempotencia de una llamada. Todos los reintentos
+// de una misma operación llevan la misma clave
+const claveIdempotencia = "idempotency-key"
+
+// Llamadas al Matchmaker que cambian su estado. Llevan una clave de
+// idempotencia para que un reintento no se aplique dos veces
+var metodosIdempotentes = map[string]bool{
+	pb.ComunicacionService_QueuePlayer_FullMethodName:            true,
+	pb.ComunicacionService_LeaveQueue_FullMethodName:             true,
+	pb.ComunicacionService_RespondReadyCheck_FullMethodName:      true,
+	pb.ComunicacionService_CreateParty_FullMethodName:            true,
+	pb.ComunicacionService_JoinParty_FullMethodName:              true,
+	pb.ComunicacionService_LeaveParty_FullMethodName:             true,
+	pb.ComunicacionService_UpdateServerStatus_FullMethodName:     true,
+	pb.ComunicacionService_AdminUpdateServerState_FullMethodName: true,
+	pb.ComunicacionService_RenewLease_FullMethodName:             true,
+	pb.ComunicacionService_ReportMatchResult_FullMethodName:      true,
+	pb.ComunicacionService_DeregisterServer_FullMethodName:       true,
+}
+
+var (
+	prefijoClaves = fmt.Sprintf("%x", rand.Uint64()) // distingue las claves de este proceso
+	ultimaClave   atomic.Uint64
+)
+
+// Agrega al contexto una clave de idempotencia nueva. Los reintentos de la
+// misma operación deben usar el contexto devuelto.
+func conIdempotencia(ctx context.Context) context.Context {
+	clave := fmt.Sprintf("%s-%d", prefijoClaves, ultimaClave.Add(1))
+	return metadata.AppendToOutgoingContext(ctx, claveIdempotencia, clave)
+}
+
+// Interceptor que agrega una clave a las llamadas que cambian el estado del
+// Matchmaker, si no traen una. Va antes del enrutador de líder, para que los
+// reintentos en otras réplicas usen la misma clave.
+func agregarClaveIdempotencia(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if metodosIdempotentes[method] {
+		if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(claveIdempotencia)) == 0 {
+			ctx = conIdempotencia(ctx)
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// ===================== SERVIDOR =========================
+
+// Tiempo durante el que se recuerda la respuesta a una clave
+const retencionIdempotencia = 10 * time.Minute
+
+type respuestaGuardada struct {
+	metodo    string
+	respuesta interface{}
+	vence     time.Time
+}
+
+var (
+	muRespuestas sync.Mutex
+	respuestas   = make(map[string]respuestaGuardada) // respuestas enviadas por clave
+	enCurso      = make(map[string]chan struct{})     // llamadas con clave que se están procesando
+)
+
+// Interceptor del servidor: las llamadas con clave de idempotencia (por
+// ejemplo, AssignMatch reintentado por el Matchmaker) se ejecutan una sola
+// vez y los reintentos reciben la respuesta original.
+func deduplicarLlamadas(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	claves := md.Get(claveIdempotencia)
+	if len(claves) == 0 {
+		return handler(ctx, req)
+	}
+	clave := claves[0]
+
+	muRespuestas.Lock()
+	for {
+		if r, ok := respuestas[clave]; ok {
+			muRespuestas.Unlock()
+			if r.metodo != info.FullMethod {
+				return nil, grpcstatus.Errorf(codes.InvalidArgument, "la clave de idempotencia ya se usó con %s", r.metodo)
+			}
+			fmt.Printf("[%s] Reintento de %s (clave %s): se devuelve la respuesta original\n", serverID, info.FullMethod, clave)
+			return r.respuesta, nil
+		}
+		listo, ok := enCurso[clave]
+		if !ok {
+			break
+		}
+		muRespuestas.Unlock()
+		select {
+		case <-listo:
+		case <-ctx.Done():
+			return nil, grpcstatus.FromContextError(ctx.Err()).Err()
+		}
+		muRespuestas.Lock()
+	}
+	listo := make(chan struct{})
+	enCurso[clave] = listo
+	muRespuestas.Unlock()
+
+	res, err := handler(ctx, req)
+
+	muRespuestas.Lock()
+	defer muRespuestas.Unlock()
+	delete(enCurso, clave)
+	close(listo)
+	ahora := time.Now()
+	for k, r := range respuestas {
+		if ahora.After(r.vence) {
+			delete(respuestas, k)
+		}
+	}
+	if err == nil {
+		respuestas[clave] = respuestaGuardada{metodo: info.FullMethod, respuesta: res, vence: ahora.Add(retencionIdempotencia)}
+	}
+	return res, err
+}
@@ -0,0 +1,116 @@
+package reloj
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+// Diferencia con la hora local a partir de la cual una marca recibida se
+// considera adelantada. Se acepta igual (el reloj híbrido no puede
+// retroceder), pero se avisa: probablemente la hora de esa máquina está mal.
+const derivaMaxima = time.Minute
+
+// Marca de un reloj lógico híbrido (HLC): la mayor hora física conocida, en
+// milisegundos, y un contador para los eventos dentro del mismo milisegundo.
+// Respeta la causalidad como un reloj de Lamport pero se mantiene cerca de la
+// hora real, así que sirve para comparar instantes de máquinas distintas.
+// Las marcas ordenan totalmente los eventos si los empates (eventos de
+// procesos distintos con la misma marca) se resuelven por proceso.
+type Marca struct {
+	Fisico int64 `json:"fisico"`
+	Logico int32 `json:"logico"`
+}
+
+// Relación entre dos marcas: -1 si a es anterior, 1 si es posterior y 0 si
+// son iguales.
+func CompararMarcas(a, b Marca) int {
+	switch {
+	case a.Fisico < b.Fisico, a.Fisico == b.Fisico && a.Logico < b.Logico:
+		return -1
+	case a == b:
+		return 0
+	}
+	return 1
+}
+
+// Parte física de la marca como hora.
+func (m Marca) Hora() time.Time {
+	return time.UnixMilli(m.Fisico)
+}
+
+// Texto de la marca; "-" para la marca cero (un proceso sin eventos todavía,
+// o un mensaje sin marca).
+func (m Marca) String() string {
+	if m == (Marca{}) {
+		return "-"
+	}
+	return fmt.Sprintf("%s+%d", m.Hora().UTC().Format("2006-01-02T15:04:05.000Z"), m.Logico)
+}
+
+// Reloj lógico híbrido de un proceso. Se usa junto al reloj vectorial: cada
+// evento que avanza uno avanza el otro.
+type Hibrido struct {
+	mu     sync.Mutex
+	ultima Marca
+}
+
+// Evento local o envío de un mensaje: devuelve la marca del evento.
+func (h *Hibrido) Avanzar() Marca {
+	return h.Recibir(Marca{})
+}
+
+// Recepción de un mensaje con la marca del remitente: la marca del evento es
+// posterior a la última del proceso y a la recibida.
+func (h *Hibrido) Recibir(remota Marca) Marca {
+	ahora := time.Now().UnixMilli()
+	h.mu.Lock()
+	anterior := h.ultima
+	h.ultima.Fisico = max(anterior.Fisico, remota.Fisico, ahora)
+	switch f := h.ultima.Fisico; {
+	case f == anterior.Fisico && f == remota.Fisico:
+		h.ultima.Logico = max(anterior.Logico, remota.Logico) + 1
+	case f == anterior.Fisico:
+		h.ultima.Logico = anterior.Logico + 1
+	case f == remota.Fisico:
+		h.ultima.Logico = remota.Logico + 1
+	default:
+		// La hora local pasó a todas las marcas conocidas
+		h.ultima.Logico = 0
+	}
+	marca := h.ultima
+	h.mu.Unlock()
+
+	if adelanto := time.Duration(remota.Fisico-ahora) * time.Millisecond; adelanto > derivaMaxima {
+		log.Printf("Marca HLC recibida %s adelantada %s respecto de la hora local", remota, adelanto)
+	}
+	return marca
+}
+
+// Última marca asignada, sin registrar un evento.
+func (h *Hibrido) Actual() Marca {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.ultima
+}
+
+// Escritor que antepone la marca actual a cada línea que escribe. Con
+// log.SetOutput, cada línea del log del proceso lleva la marca del último
+// evento.
+func (h *Hibrido) Escritor(w io.Writer) io.Writer {
+	return escritorHLC{h: h, w: w}
+}
+
+type escritorHLC struct {
+	h *Hibrido
+	w io.Writer
+}
+
+func (e escritorHLC) Write(p []byte) (int, error) {
+	if _, err := fmt.Fprintf(e.w, "[HLC %s] ", e.h.Actual()); err != nil {
+		return 0, err
+	}
+	return e.w.Write(p)
+}
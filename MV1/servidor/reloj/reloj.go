@@ -0,0 +1,197 @@
+// Package reloj implementa los relojes vectoriales y los relojes lógicos
+// híbridos con que el Matchmaker, los servidores de juego y los jugadores
+// ordenan sus eventos.
+//
+// La copia de referencia está en MV4/reloj. Cada módulo tiene la suya, igual
+// que el proto: los archivos son idénticos en todas y proto.go solo cambia en
+// la ruta del paquete generado.
+package reloj
+
+import "sync"
+
+// Reloj vectorial: proceso -> cantidad de eventos de ese proceso que se
+// conocen. Un proceso que no figura equivale a 0.
+type Reloj map[string]int32
+
+// Relación causal entre dos relojes.
+type Orden int
+
+const (
+	Igual       Orden = iota // mismos valores en todos los procesos
+	Antes                    // el primero ocurrió antes que el segundo
+	Despues                  // el primero ocurrió después que el segundo
+	Concurrente              // ninguno conocía al otro
+)
+
+func (o Orden) String() string {
+	switch o {
+	case Igual:
+		return "IGUAL"
+	case Antes:
+		return "ANTES"
+	case Despues:
+		return "DESPUÉS"
+	case Concurrente:
+		return "CONCURRENTE"
+	}
+	return "DESCONOCIDO"
+}
+
+// Reloj con los procesos indicados en 0.
+func Nuevo(procesos ...string) Reloj {
+	r := make(Reloj, len(procesos))
+	for _, p := range procesos {
+		r[p] = 0
+	}
+	return r
+}
+
+// Registra un evento local del proceso y devuelve su nuevo valor.
+func (r Reloj) Incrementar(proceso string) int32 {
+	r[proceso]++
+	return r[proceso]
+}
+
+// Incorpora lo que sabe otro reloj: en cada proceso queda el máximo de los dos.
+func (r Reloj) Fusionar(otro Reloj) {
+	for p, v := range otro {
+		if actual, ok := r[p]; !ok || v > actual {
+			r[p] = v
+		}
+	}
+}
+
+// Copia independiente del reloj. La copia de un reloj nil es un reloj vacío.
+func (r Reloj) Copia() Reloj {
+	c := make(Reloj, len(r))
+	for p, v := range r {
+		c[p] = v
+	}
+	return c
+}
+
+// Quita del reloj los componentes de procesos que se fueron para siempre.
+// Solo puede hacerse cuando ningún reloj que se vaya a comparar con este
+// conserva un valor de esos procesos: dos relojes sin el componente se
+// comparan igual que antes, porque un proceso que no figura equivale a 0.
+func (r Reloj) Quitar(procesos ...string) {
+	for _, p := range procesos {
+		delete(r, p)
+	}
+}
+
+// Relación causal entre a y b: a ocurrió antes que b si b conoce todo lo que
+// conoce a y algo más; son concurrentes si cada uno conoce algo que el otro
+// no.
+func Comparar(a, b Reloj) Orden {
+	menor, mayor := false, false
+	for p, va := range a {
+		switch vb := b[p]; {
+		case va < vb:
+			menor = true
+		case va > vb:
+			mayor = true
+		}
+	}
+	for p, vb := range b {
+		if _, ok := a[p]; !ok && vb > 0 {
+			menor = true
+		}
+	}
+
+	switch {
+	case menor && mayor:
+		return Concurrente
+	case menor:
+		return Antes
+	case mayor:
+		return Despues
+	}
+	return Igual
+}
+
+// Indica si el evento de r ocurrió antes que el de otro.
+func (r Reloj) AntesDe(otro Reloj) bool {
+	return Comparar(r, otro) == Antes
+}
+
+// Indica si los eventos de r y otro son concurrentes.
+func (r Reloj) ConcurrenteCon(otro Reloj) bool {
+	return Comparar(r, otro) == Concurrente
+}
+
+// ===================== RELOJ DE UN PROCESO =========================
+
+// Reloj de un proceso que lo usa desde varias goroutines (por ejemplo, un
+// servidor de juego que atiende RPCs mientras juega partidas). Cada evento
+// del proceso avanza su componente y su reloj híbrido, y queda anotado en el
+// registro, si tiene uno. Solo entrega copias, para que gRPC nunca serialice
+// el mapa mientras otro lo modifica.
+type Proceso struct {
+	mu       sync.Mutex
+	id       string
+	r        Reloj
+	hlc      Hibrido
+	registro *Registro
+}
+
+// Reloj del proceso id. Empieza conociendo solo al propio proceso: los demás
+// se incorporan a medida que llegan sus mensajes. Los eventos se anotan en
+// registro (nil si no se registran).
+func NuevoProceso(id string, registro *Registro) *Proceso {
+	return &Proceso{id: id, r: Nuevo(id), registro: registro}
+}
+
+// Evento interno del proceso. Devuelve una copia del reloj.
+func (p *Proceso) Local(evento string) Reloj {
+	r, _ := p.avanzar(Local, evento, nil, Marca{})
+	return r
+}
+
+// Envío de un mensaje. Devuelve la copia del reloj que debe llevar.
+func (p *Proceso) Enviar(evento string) Reloj {
+	r, _ := p.avanzar(Envio, evento, nil, Marca{})
+	return r
+}
+
+// Recepción de un mensaje con el reloj remoto: se incorpora lo que sabía el
+// remitente. Devuelve una copia del reloj.
+func (p *Proceso) Recibir(evento string, remoto Reloj) Reloj {
+	r, _ := p.avanzar(Recepcion, evento, remoto, Marca{})
+	return r
+}
+
+// Olvida los componentes de procesos dados de baja (ver Reloj.Quitar). El
+// componente propio nunca se olvida.
+func (p *Proceso) Olvidar(procesos ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, q := range procesos {
+		if q != p.id {
+			delete(p.r, q)
+		}
+	}
+}
+
+// Copia del valor actual, sin registrar un evento.
+func (p *Proceso) Copia() Reloj {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.r.Copia()
+}
+
+// Reloj híbrido del proceso, por ejemplo para marcar su log con
+// Hibrido.Escritor.
+func (p *Proceso) Hibrido() *Hibrido {
+	return &p.hlc
+}
+
+func (p *Proceso) avanzar(tipo Tipo, evento string, remoto Reloj, remota Marca) (Reloj, Marca) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.r.Fusionar(remoto)
+	p.r.Incrementar(p.id)
+	marca := p.hlc.Recibir(remota)
+	p.registro.Anotar(tipo, evento, p.r, marca)
+	return p.r.Copia(), marca
+}
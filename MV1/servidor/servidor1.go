@@ -2,31 +2,41 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"net"
+	"os"
+	"sync"
 	"time"
 
 	pb "servidor/proto/grpc-server/proto"
+	"servidor/reloj"
 
 	"google.golang.org/grpc"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 const (
-	serverID       = "GameServer1"
-	serverAddr     = "localhost:60051" // Cambia este puerto en GameServer2 y 3
-	matchmakerAddr = "localhost:50051"
+	serverID   = "GameServer1"
+	serverAddr = "localhost:60051" // Cambia este puerto en GameServer2 y 3
+
+	// Capacidad relativa del servidor: el Matchmaker le asigna más partidas
+	// cuanto mayor sea, si usa la selección ponderada por capacidad
+	capacidad = 1
+
+	// Reintentos para informar el resultado de una partida al Matchmaker
+	intentosReporte = 5
+	esperaReporte   = 2 * time.Second
 )
 
 var (
+	// Estado del servidor. Lo cambian la partida, AssignMatch y el drenaje
+	// desde goroutines distintas: se lee con estadoActual
+	muEstado    sync.Mutex
 	status      = "DISPONIBLE"
-	vectorClock = map[string]int32{
-		"GameServer1": 0,
-		"Matchmaker":  0,
-		"Player1":     0,
-		"Player2":     0,
-	}
+	vectorClock *reloj.Proceso
 )
 
 type gameServer struct {
@@ -34,31 +44,72 @@ type gameServer struct {
 }
 
 func main() {
+	intervalo := flag.Duration("renovacion", 3*time.Second, "intervalo de renovación del lease en el Matchmaker")
+	eventos := flag.String("eventos", "eventos_gameserver1.jsonl", "registro causal de eventos (vacío para no registrarlos)")
+	flag.Parse()
+
+	registro, err := reloj.AbrirRegistro(serverID, *eventos)
+	if err != nil {
+		log.Fatalf("[GameServer1] No se pudo abrir el registro de eventos: %v", err)
+	}
+	defer registro.Cerrar()
+	vectorClock = reloj.NuevoProceso(serverID, registro)
+	// Cada línea del log lleva la marca HLC del último evento del proceso
+	log.SetOutput(vectorClock.Hibrido().Escritor(os.Stderr))
+
 	// Inicia el servidor gRPC
 	lis, err := net.Listen("tcp", serverAddr)
 	if err != nil {
 		log.Fatalf("[GameServer1] No se pudo escuchar en %s: %v", serverAddr, err)
 	}
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.UnaryInterceptor(deduplicarLlamadas))
 	pb.RegisterComunicacionServiceServer(s, &gameServer{})
 
 	// Registra con el Matchmaker al arrancar
-	go registrarConMatchmaker()
+	go registrarConMatchmaker(*intervalo)
+
+	listo := make(chan struct{})
+	go esperarRetiro(s, listo)
 
 	fmt.Printf("[GameServer1] Servidor escuchando en %s\n", serverAddr)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("[GameServer1] Fallo al servir: %v", err)
 	}
+	<-listo
 }
 
-// Implementa AssignMatch (servidor gRPC)
+// Implementa AssignMatch (servidor gRPC). Solo confirma la recepción: la
+// partida se juega aparte y el resultado se informa con ReportMatchResult
 func (gs *gameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
-	fmt.Printf("[GameServer1] Recibida asignación de partida: %d para jugadores %v\n", req.MatchId, req.PlayersIds)
+	if err := admitirPartida(); err != nil {
+		return nil, err
+	}
+	vectorClock.RecibirMensaje(fmt.Sprintf("AssignMatch de la partida %d", req.MatchId), req.VectorClock)
+	fmt.Printf("[GameServer1] Recibida asignación de partida: %d (%s)\n", req.MatchId, req.GameMode)
+	var jugadores []int32
+	for _, team := range req.Teams {
+		fmt.Printf("[GameServer1]   Equipo %d: jugadores %v\n", team.TeamId, team.PlayersIds)
+		jugadores = append(jugadores, team.PlayersIds...)
+	}
 
 	cambiarEstado("OCUPADO")
+	go jugarPartida(req)
+
+	return &pb.AssignMatchResponse{
+		Message:            "Partida recibida",
+		MatchId:            req.MatchId,
+		PlayersIds:         jugadores,
+		MatchServerAddress: serverAddr,
+		VectorClock:        vectorClock.Proto(),
+	}, nil
+}
+
+// Simula la partida y al terminar informa el resultado al Matchmaker
+func jugarPartida(req *pb.AssignMatchRequest) {
 	actualizarEstadoEnMatchmaker("OCUPADO")
 
 	// Simula la partida
+	inicio := time.Now()
 	duracion := rand.Intn(11) + 10 // entre 10 y 20 segundos
 	fmt.Printf("[GameServer1] Simulando partida por %d segundos...\n", duracion)
 	time.Sleep(time.Duration(duracion) * time.Second)
@@ -69,50 +120,85 @@ func (gs *gameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchReques
 		cambiarEstado("CAIDO")
 		actualizarEstadoEnMatchmaker("CAIDO")
 		// Puedes usar os.Exit(1) si prefieres una caída inmediata
+		partidas.Done()
 		select {} // Queda "caído" (no responde más)
 	}
 
-	// Finaliza la partida
-	cambiarEstado("DISPONIBLE")
-	actualizarEstadoEnMatchmaker("DISPONIBLE")
+	// Finaliza la partida y elige al equipo ganador
+	var ganador int32
+	if len(req.Teams) > 0 {
+		ganador = req.Teams[rand.Intn(len(req.Teams))].TeamId
+	}
+	fmt.Printf("[GameServer1] Partida %d terminada. Equipo ganador: %d\n", req.MatchId, ganador)
+	estadoFinal := "DISPONIBLE"
+	if retirandose() {
+		estadoFinal = "DRAINING"
+	}
+	cambiarEstado(estadoFinal)
+	informarResultado(req.MatchId, ganador, time.Since(inicio))
+	actualizarEstadoEnMatchmaker(estadoFinal)
+	terminarPartida()
+}
 
-	return &pb.AssignMatchResponse{
-		Message:            "Partida finalizada",
-		MatchId:            req.MatchId,
-		PlayersIds:         req.PlayersIds,
-		MatchServerAddress: serverAddr,
-		VectorClock:        &pb.VectorClock{Clocks: vectorClock},
+// Implementa PingServer: el Matchmaker lo usa para verificar que el servidor
+// sigue vivo cuando lleva un tiempo sin informar su estado
+func (gs *gameServer) PingServer(ctx context.Context, req *pb.ServerId) (*pb.PingResponse, error) {
+	// Un servidor caído no responde (igual que en la simulación de caída):
+	// la llamada espera a que venza el plazo de quien hace el ping, para no
+	// dejar una goroutine bloqueada por cada ping
+	estado := estadoActual()
+	if estado == "CAIDO" {
+		<-ctx.Done()
+		return nil, grpcstatus.FromContextError(ctx.Err()).Err()
+	}
+	vectorClock.RecibirMensaje("PingServer del Matchmaker", req.VectorClock)
+	return &pb.PingResponse{
+		Status:      estado,
+		Message:     serverID + " activo",
+		VectorClock: vectorClock.Proto(),
 	}, nil
 }
 
+// Estado actual del servidor
+func estadoActual() string {
+	muEstado.Lock()
+	defer muEstado.Unlock()
+	return status
+}
+
 // Cambia el estado interno y actualiza el reloj vectorial
 func cambiarEstado(nuevo string) {
+	muEstado.Lock()
 	status = nuevo
-	vectorClock[serverID]++
-	log.Printf("[GameServer1] Estado cambiado a %s. VectorClock: %+v\n", nuevo, vectorClock)
+	muEstado.Unlock()
+	vc := vectorClock.Local("Estado " + nuevo)
+	log.Printf("[GameServer1] Estado cambiado a %s. VectorClock: %+v\n", nuevo, vc)
 }
 
 // Registra el servidor en el Matchmaker
-func registrarConMatchmaker() {
+func registrarConMatchmaker(intervalo time.Duration) {
 	log.Println("[GameServer1] Registrando estado inicial en el Matchmaker...")
 	actualizarEstadoEnMatchmaker("DISPONIBLE")
+
+	// Desde aquí el servidor mantiene su registro renovando el lease
+	renovarLease(intervalo)
 }
 
 // Actualiza el estado en el Matchmaker
 func actualizarEstadoEnMatchmaker(nuevoEstado string) {
-	conn, err := grpc.Dial(matchmakerAddr, grpc.WithInsecure())
+	conn, err := conectarMatchmaker()
 	if err != nil {
 		log.Printf("[GameServer1] No se pudo conectar al Matchmaker: %v", err)
 		return
 	}
-	defer conn.Close()
 
 	client := pb.NewComunicacionServiceClient(conn)
 	req := &pb.ServerStatusUpdateRequest{
 		ServerId:    serverID,
 		NewStatus:   nuevoEstado,
 		Address:     serverAddr,
-		VectorClock: &pb.VectorClock{Clocks: vectorClock},
+		VectorClock: vectorClock.EnviarMensaje("UpdateServerStatus " + nuevoEstado),
+		Capacity:    capacidad,
 	}
 
 	res, err := client.UpdateServerStatus(context.Background(), req)
@@ -120,5 +206,80 @@ func actualizarEstadoEnMatchmaker(nuevoEstado string) {
 		log.Printf("[GameServer1] Error al actualizar estado en Matchmaker: %v", err)
 	} else {
 		log.Printf("[GameServer1] Estado actualizado en Matchmaker. Respuesta: %s\n", res.StatusCode)
+		vectorClock.RecibirMensaje("Respuesta de UpdateServerStatus", res.VectorClock)
+	}
+}
+
+// Informa al Matchmaker el resultado de una partida. Reintenta si el
+// Matchmaker no responde, para que el resultado no se pierda
+func informarResultado(matchID, ganador int32, duracion time.Duration) {
+	// La misma clave en todos los intentos: el resultado se registra una vez
+	ctx := conIdempotencia(context.Background())
+	for intento := 1; intento <= intentosReporte; intento++ {
+		conn, err := conectarMatchmaker()
+		if err != nil {
+			log.Printf("[GameServer1] No se pudo conectar al Matchmaker: %v", err)
+			time.Sleep(esperaReporte)
+			continue
+		}
+		client := pb.NewComunicacionServiceClient(conn)
+		res, err := client.ReportMatchResult(ctx, &pb.MatchResultRequest{
+			MatchId:       matchID,
+			ServerId:      serverID,
+			WinningTeamId: ganador,
+			DurationMs:    duracion.Milliseconds(),
+			VectorClock:   vectorClock.EnviarMensaje(fmt.Sprintf("ReportMatchResult de la partida %d", matchID)),
+		})
+
+		if err == nil {
+			vectorClock.RecibirMensaje("Respuesta de ReportMatchResult", res.VectorClock)
+			log.Printf("[GameServer1] Resultado de la partida %d informado: %s (%s)", matchID, res.StatusCode, res.Message)
+			return
+		}
+		log.Printf("[GameServer1] Error al informar el resultado de la partida %d (intento %d/%d): %v", matchID, intento, intentosReporte, err)
+		time.Sleep(esperaReporte)
+	}
+}
+
+// Renueva periódicamente el lease en el Matchmaker. Un servidor caído deja de
+// renovarlo, y el Matchmaker deja de asignarle partidas cuando vence. Si el
+// Matchmaker indica que el servidor está en DRAINING, el servidor se retira
+func renovarLease(intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if estadoActual() == "CAIDO" {
+			continue
+		}
+
+		conn, err := conectarMatchmaker()
+		if err != nil {
+			log.Printf("[GameServer1] No se pudo conectar al Matchmaker: %v", err)
+			continue
+		}
+		client := pb.NewComunicacionServiceClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), intervalo)
+		res, err := client.RenewLease(ctx, &pb.LeaseRequest{
+			ServerId:        serverID,
+			RenewIntervalMs: int32(intervalo / time.Millisecond),
+			VectorClock:     vectorClock.EnviarMensaje("RenewLease"),
+		})
+		cancel()
+
+		if err != nil {
+			log.Printf("[GameServer1] Error al renovar el lease: %v", err)
+			continue
+		}
+		vectorClock.RecibirMensaje("Respuesta de RenewLease", res.VectorClock)
+		if res.Drain {
+			pedirDrenaje("pedido del administrador")
+		}
+		// El Matchmaker no conoce al servidor (por ejemplo, porque se reinició).
+		// Un servidor que se está retirando no vuelve a registrarse
+		if res.StatusCode != "SUCCESS" && !retirandose() {
+			log.Println("[GameServer1] Lease rechazado. Registrando de nuevo en el Matchmaker...")
+			actualizarEstadoEnMatchmaker(estadoActual())
+		}
 	}
 }
@@ -2,38 +2,58 @@ package main
 
 import (
 	"MV2/proto/grpc-server/proto"
+	"MV2/reloj"
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"google.golang.org/grpc"
 )
 
 var jugador *proto.Jugador
-var vectorClock = map[string]int32{}
+var vectorClock *reloj.Proceso
 
 func main() {
+	eventos := flag.String("eventos", "eventos_player2.jsonl", "registro causal de eventos (vacío para no registrarlos)")
+	flag.Parse()
+
+	registro, err := reloj.AbrirRegistro("Player2", *eventos)
+	if err != nil {
+		log.Fatalf("No se pudo abrir el registro de eventos: %v", err)
+	}
+	defer registro.Cerrar()
+	vectorClock = reloj.NuevoProceso("Player2", registro)
+	// Cada línea del log lleva la marca HLC del último evento del proceso
+	log.SetOutput(vectorClock.Hibrido().Escritor(os.Stderr))
+
 	// Captura nombre por consola
 	fmt.Print("Ingrese el nombre del jugador 2: ")
 	reader := bufio.NewReader(os.Stdin)
 	nombre, _ := reader.ReadString('\n')
 	nombre = strings.TrimSpace(nombre)
 
+	// Modo de juego preferido (Casual por defecto)
+	fmt.Print("Ingrese el modo de juego (Casual / Ranked / Duos / Equipos / FFA) [Casual]: ")
+	modo, _ := reader.ReadString('\n')
+	modo = strings.TrimSpace(modo)
+	if modo == "" {
+		modo = "Casual"
+	}
+
 	jugador = &proto.Jugador{
 		Id:                 2,
 		Name:               nombre,
-		GameModePreference: "Casual",
+		GameModePreference: modo,
 		Status:             "IDLE",
 	}
 
-	// Inicializa vector clock
-	vectorClock["Player2"] = 0
-
-	// Conexión gRPC con Matchmaker
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	// Conexión gRPC con el cluster de matchmakers
+	conn, err := conectarMatchmaker()
 	if err != nil {
 		log.Fatalf("No se pudo conectar: %v", err)
 	}
@@ -45,16 +65,19 @@ func main() {
 		// Menú
 		fmt.Println("\n--- Menú Jugador 2 ---")
 		fmt.Println("1. Unirse a cola de emparejamiento")
-		fmt.Println("2. Consultar estado")
-		fmt.Println("3. Salir")
+		fmt.Println("2. Salir de la cola (cancelar búsqueda)")
+		fmt.Println("3. Consultar estado")
+		fmt.Println("4. Crear grupo")
+		fmt.Println("5. Unirse a un grupo")
+		fmt.Println("6. Salir del grupo")
+		fmt.Println("7. Salir")
 		fmt.Print("Seleccione una opción: ")
 		opcion, _ := reader.ReadString('\n')
 		opcion = strings.TrimSpace(opcion)
 
 		switch opcion {
 		case "1":
-			vectorClock["Player2"]++
-			vc := &proto.VectorClock{Clocks: vectorClock}
+			vc := vectorClock.EnviarMensaje("QueuePlayer")
 
 			req := &proto.PlayerInfoRequest{
 				PlayerId:           jugador.Id,
@@ -67,11 +90,14 @@ func main() {
 				log.Println("Error al hacer QueuePlayer:", err)
 			} else {
 				fmt.Println("Respuesta del servidor:", res.Message)
-				mergeVectorClock(res.VectorClock)
+				vectorClock.RecibirMensaje("Respuesta de QueuePlayer", res.VectorClock)
 			}
 
 		case "2":
-			vc := &proto.VectorClock{Clocks: vectorClock}
+			leaveQueue(client)
+
+		case "3":
+			vc := vectorClock.EnviarMensaje("GetPlayerStatus")
 			req := &proto.PlayerStatusRequest{
 				PlayerId:    jugador.Id,
 				VectorClock: vc,
@@ -82,10 +108,48 @@ func main() {
 			} else {
 				fmt.Printf("Estado actual: %s\n", res.Status)
 				fmt.Printf("Match ID: %d, Servidor: %s\n", res.MatchId, res.MatchServerAddress)
-				mergeVectorClock(res.VectorClock)
+				if m := res.Match; m != nil {
+					fmt.Printf("Partida %d (%s) en %s | Estado: %s | Inicio: %s\n", m.MatchId, m.GameMode, m.ServerId, m.Status, m.StartTime)
+					for _, team := range m.Teams {
+						fmt.Printf("  Equipo %d: %v\n", team.TeamId, team.PlayersIds)
+					}
+				}
+				fmt.Printf("Rating: %d\n", res.Rating)
+				if res.PartyId != 0 {
+					fmt.Printf("Grupo: %d\n", res.PartyId)
+				}
+				vectorClock.RecibirMensaje("Respuesta de GetPlayerStatus", res.VectorClock)
+
+				if res.Status == "IN QUEUE" {
+					estimarEspera(client)
+				}
+				if res.Status == "MATCH FOUND" {
+					fmt.Printf("¡Partida encontrada! Tienes %d segundos para aceptar.\n", res.ReadyCheckSecondsLeft)
+					fmt.Print("¿Aceptar la partida? (s/n): ")
+					respuesta, _ := reader.ReadString('\n')
+					respuesta = strings.ToLower(strings.TrimSpace(respuesta))
+					responderReadyCheck(client, res.MatchId, respuesta == "s")
+				}
 			}
 
-		case "3":
+		case "4":
+			operacionGrupo("CreateParty", client.CreateParty, 0)
+
+		case "5":
+			fmt.Print("Ingrese el ID del grupo: ")
+			entrada, _ := reader.ReadString('\n')
+			partyID, err := strconv.Atoi(strings.TrimSpace(entrada))
+			if err != nil {
+				fmt.Println("ID de grupo inválido.")
+				continue
+			}
+			operacionGrupo("JoinParty", client.JoinParty, int32(partyID))
+
+		case "6":
+			operacionGrupo("LeaveParty", client.LeaveParty, 0)
+
+		case "7":
+			leaveQueue(client) // evita dejar una entrada fantasma en la cola
 			fmt.Println("Saliendo del juego.")
 			return
 
@@ -95,10 +159,80 @@ func main() {
 	}
 }
 
-func mergeVectorClock(remote *proto.VectorClock) {
-	for k, v := range remote.Clocks {
-		if local, ok := vectorClock[k]; !ok || v > local {
-			vectorClock[k] = v
-		}
+func leaveQueue(client proto.ComunicacionServiceClient) {
+	req := &proto.LeaveQueueRequest{
+		PlayerId:    jugador.Id,
+		VectorClock: vectorClock.EnviarMensaje("LeaveQueue"),
+	}
+
+	res, err := client.LeaveQueue(context.Background(), req)
+	if err != nil {
+		log.Println("Error al salir de la cola:", err)
+		return
+	}
+
+	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
+	vectorClock.RecibirMensaje("Respuesta de LeaveQueue", res.VectorClock)
+}
+
+// Consulta al Matchmaker el tiempo en cola y la espera estimada
+func estimarEspera(client proto.ComunicacionServiceClient) {
+	req := &proto.WaitTimeRequest{
+		PlayerId:    jugador.Id,
+		GameMode:    jugador.GameModePreference,
+		VectorClock: vectorClock.EnviarMensaje("EstimateWaitTime"),
+	}
+
+	res, err := client.EstimateWaitTime(context.Background(), req)
+	if err != nil {
+		log.Println("Error al estimar la espera:", err)
+		return
+	}
+
+	fmt.Printf("Tiempo en cola: %s | Jugadores adelante: %d\n", res.TimeInQueue, res.PlayersAhead)
+	if res.StatusCode == "SUCCESS" {
+		fmt.Printf("Espera estimada: %ds\n", res.EstimatedWaitSeconds)
+	} else {
+		fmt.Println("Espera estimada: desconocida (aún no hay partidas recientes en este modo)")
+	}
+	vectorClock.RecibirMensaje("Respuesta de EstimateWaitTime", res.VectorClock)
+}
+
+func responderReadyCheck(client proto.ComunicacionServiceClient, matchID int32, aceptar bool) {
+	req := &proto.ReadyCheckRequest{
+		PlayerId:    jugador.Id,
+		MatchId:     matchID,
+		Accept:      aceptar,
+		VectorClock: vectorClock.EnviarMensaje("RespondReadyCheck"),
+	}
+
+	res, err := client.RespondReadyCheck(context.Background(), req)
+	if err != nil {
+		log.Println("Error al responder la partida:", err)
+		return
+	}
+
+	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
+	vectorClock.RecibirMensaje("Respuesta de RespondReadyCheck", res.VectorClock)
+}
+
+// Ejecuta una operación de grupo (crear, unirse o salir) en el Matchmaker
+func operacionGrupo(nombre string, op func(context.Context, *proto.PartyRequest, ...grpc.CallOption) (*proto.PartyResponse, error), partyID int32) {
+	req := &proto.PartyRequest{
+		PlayerId:    jugador.Id,
+		PartyId:     partyID,
+		VectorClock: vectorClock.EnviarMensaje(nombre),
+	}
+
+	res, err := op(context.Background(), req)
+	if err != nil {
+		log.Printf("Error al hacer %s: %v", nombre, err)
+		return
+	}
+
+	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
+	if res.PartyId != 0 {
+		fmt.Printf("Grupo %d | Líder: %d | Miembros: %v\n", res.PartyId, res.LeaderId, res.MembersIds)
 	}
+	vectorClock.RecibirMensaje("Respuesta de "+nombre, res.VectorClock)
 }
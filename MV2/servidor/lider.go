@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// Réplicas del cluster de matchmakers. Cualquiera sirve para empezar: las
+// que no son líder responden con la dirección del líder.
+var replicasMatchmaker = flag.String("matchmakers", "localhost:50051,localhost:50052,localhost:50053",
+	"direcciones de las réplicas del Matchmaker separadas por comas")
+
+const (
+	claveLider         = "lider" // metadata con la dirección del líder
+	esperaSinLider     = 300 * time.Millisecond
+	intentosPorReplica = 3
+)
+
+var enrutador = &enrutadorLider{conns: make(map[string]*grpc.ClientConn)}
+
+var (
+	conexionMatchmaker    *grpc.ClientConn
+	errConexionMatchmaker error
+	conectarUnaVez        sync.Once
+)
+
+// Envía las llamadas al líder del cluster de matchmakers, siguiéndolo cuando
+// cambia. Mantiene una conexión por réplica, abierta mientras dure el proceso.
+type enrutadorLider struct {
+	mu    sync.Mutex
+	lider string // último líder conocido
+	conns map[string]*grpc.ClientConn
+}
+
+// Conexión con el cluster de matchmakers, compartida por todas las llamadas
+// del proceso: no debe cerrarse después de cada llamada. Solo sirve para
+// crear el cliente; cada llamada se envía al líder del momento por la
+// conexión que el enrutador mantiene con él.
+func conectarMatchmaker() (*grpc.ClientConn, error) {
+	conectarUnaVez.Do(func() {
+		conexionMatchmaker, errConexionMatchmaker = grpc.NewClient(replicas()[0], grpc.WithInsecure(),
+			grpc.WithChainUnaryInterceptor(agregarClaveIdempotencia, enrutador.interceptor))
+	})
+	return conexionMatchmaker, errConexionMatchmaker
+}
+
+// Interceptor de las llamadas al Matchmaker. Si la réplica no es el líder se
+// reintenta en el líder que indica; si no responde o el cluster está
+// eligiendo líder, se prueba con la siguiente réplica.
+func (e *enrutadorLider) interceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	addr := e.actual()
+	var err error
+	for intento := 0; intento < intentosPorReplica*len(replicas()); intento++ {
+		var trailer metadata.MD
+		conn, errConn := e.conn(addr)
+		if errConn != nil {
+			return errConn
+		}
+		err = conn.Invoke(ctx, method, req, reply, append(opts, grpc.Trailer(&trailer))...)
+		switch grpcstatus.Code(err) {
+		case codes.OK:
+			e.fijar(addr)
+			return nil
+		case codes.FailedPrecondition:
+			if lider := trailer.Get(claveLider); len(lider) > 0 {
+				addr = lider[0]
+				continue
+			}
+		case codes.Unavailable:
+			addr = e.siguiente(addr)
+			select {
+			case <-time.After(esperaSinLider):
+				continue
+			case <-ctx.Done():
+				return err
+			}
+		}
+		return err
+	}
+	return err
+}
+
+func (e *enrutadorLider) actual() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.lider != "" {
+		return e.lider
+	}
+	return replicas()[0]
+}
+
+func (e *enrutadorLider) fijar(addr string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lider = addr
+}
+
+// Réplica que sigue a addr en la lista. Olvida el líder conocido, que dejó de
+// responder como tal.
+func (e *enrutadorLider) siguiente(addr string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lider = ""
+	lista := replicas()
+	for i, r := range lista {
+		if r == addr {
+			return lista[(i+1)%len(lista)]
+		}
+	}
+	return lista[0]
+}
+
+// Conexión con la réplica, creada la primera vez que se usa. gRPC la
+// restablece sola si se pierde; si está esperando para reintentar, se
+// reintenta de inmediato para no hacer esperar a la llamada.
+func (e *enrutadorLider) conn(addr string) (*grpc.ClientConn, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if c, ok := e.conns[addr]; ok {
+		if c.GetState() == connectivity.TransientFailure {
+			c.ResetConnectBackoff()
+		}
+		return c, nil
+	}
+	c, err := grpc.NewClient(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	e.conns[addr] = c
+	go vigilarConexion(addr, c)
+	return c, nil
+}
+
+// Estado de la conexión con cada réplica usada hasta ahora.
+func (e *enrutadorLider) estados() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var lista []string
+	for addr, c := range e.conns {
+		lista = append(lista, addr+": "+c.GetState().String())
+	}
+	sort.Strings(lista)
+	return lista
+}
+
+// Informa cuando se pierde o se restablece una conexión que ya funcionaba.
+func vigilarConexion(addr string, c *grpc.ClientConn) {
+	conectada := false
+	estado := c.GetState()
+	for c.WaitForStateChange(context.Background(), estado) {
+		anterior := estado
+		estado = c.GetState()
+		switch {
+		case estado == connectivity.Ready && conectada:
+			log.Printf("Conexión con el Matchmaker %s restablecida", addr)
+		case estado == connectivity.Ready:
+			conectada = true
+		case anterior == connectivity.Ready && estado != connectivity.Shutdown:
+			log.Printf("Conexión con el Matchmaker %s perdida. Se reconectará al volver a usarla", addr)
+		}
+	}
+}
+
+func replicas() []string {
+	var lista []string
+	for _, r := range strings.Split(*replicasMatchmaker, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			lista = append(lista, r)
+		}
+	}
+	return lista
+}
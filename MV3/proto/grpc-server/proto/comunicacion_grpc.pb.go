@@ -21,11 +21,23 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	ComunicacionService_QueuePlayer_FullMethodName            = "/comunicacion.ComunicacionService/QueuePlayer"
 	ComunicacionService_GetPlayerStatus_FullMethodName        = "/comunicacion.ComunicacionService/GetPlayerStatus"
+	ComunicacionService_LeaveQueue_FullMethodName             = "/comunicacion.ComunicacionService/LeaveQueue"
+	ComunicacionService_RespondReadyCheck_FullMethodName      = "/comunicacion.ComunicacionService/RespondReadyCheck"
+	ComunicacionService_EstimateWaitTime_FullMethodName       = "/comunicacion.ComunicacionService/EstimateWaitTime"
+	ComunicacionService_CreateParty_FullMethodName            = "/comunicacion.ComunicacionService/CreateParty"
+	ComunicacionService_JoinParty_FullMethodName              = "/comunicacion.ComunicacionService/JoinParty"
+	ComunicacionService_LeaveParty_FullMethodName             = "/comunicacion.ComunicacionService/LeaveParty"
 	ComunicacionService_AssignMatch_FullMethodName            = "/comunicacion.ComunicacionService/AssignMatch"
+	ComunicacionService_ReportMatchResult_FullMethodName      = "/comunicacion.ComunicacionService/ReportMatchResult"
 	ComunicacionService_UpdateServerStatus_FullMethodName     = "/comunicacion.ComunicacionService/UpdateServerStatus"
 	ComunicacionService_AdminGetSystemStatus_FullMethodName   = "/comunicacion.ComunicacionService/AdminGetSystemStatus"
 	ComunicacionService_AdminUpdateServerState_FullMethodName = "/comunicacion.ComunicacionService/AdminUpdateServerState"
 	ComunicacionService_PingServer_FullMethodName             = "/comunicacion.ComunicacionService/PingServer"
+	ComunicacionService_RenewLease_FullMethodName             = "/comunicacion.ComunicacionService/RenewLease"
+	ComunicacionService_DeregisterServer_FullMethodName       = "/comunicacion.ComunicacionService/DeregisterServer"
+	ComunicacionService_RequestVote_FullMethodName            = "/comunicacion.ComunicacionService/RequestVote"
+	ComunicacionService_AppendEntries_FullMethodName          = "/comunicacion.ComunicacionService/AppendEntries"
+	ComunicacionService_InstallSnapshot_FullMethodName        = "/comunicacion.ComunicacionService/InstallSnapshot"
 )
 
 // ComunicacionServiceClient is the client API for ComunicacionService service.
@@ -36,16 +48,38 @@ type ComunicacionServiceClient interface {
 	QueuePlayer(ctx context.Context, in *PlayerInfoRequest, opts ...grpc.CallOption) (*QueuePlayerResponse, error)
 	// funcionalidad para consultar el estado actual del jugador
 	GetPlayerStatus(ctx context.Context, in *PlayerStatusRequest, opts ...grpc.CallOption) (*PlayerStatusResponse, error)
+	// funcionalidad para salir de la cola (cancelar la búsqueda de partida)
+	LeaveQueue(ctx context.Context, in *LeaveQueueRequest, opts ...grpc.CallOption) (*LeaveQueueResponse, error)
+	// funcionalidad para aceptar o rechazar una partida encontrada (ready-check)
+	RespondReadyCheck(ctx context.Context, in *ReadyCheckRequest, opts ...grpc.CallOption) (*ReadyCheckResponse, error)
+	// funcionalidad para estimar el tiempo de espera restante en la cola
+	EstimateWaitTime(ctx context.Context, in *WaitTimeRequest, opts ...grpc.CallOption) (*WaitTimeResponse, error)
+	// funcionalidad para crear un grupo (party); quien lo crea queda como líder
+	CreateParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error)
+	// funcionalidad para unirse a un grupo existente
+	JoinParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error)
+	// funcionalidad para salir del grupo actual
+	LeaveParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error)
 	// funcionalidad de matchmaker para iniciar una partida
 	AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error)
+	// funcionalidad para que el servidor de juego informe el resultado de una partida terminada
+	ReportMatchResult(ctx context.Context, in *MatchResultRequest, opts ...grpc.CallOption) (*MatchResultResponse, error)
 	// funcionalidad para informar cambios de estado del servidor
 	UpdateServerStatus(ctx context.Context, in *ServerStatusUpdateRequest, opts ...grpc.CallOption) (*ServerStatusUpdateResponse, error)
 	// funcionalidad para el Cliente Administrador. Devuelve el estado de todos los servidores y las colas de jugadores
 	AdminGetSystemStatus(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*SystemStatusResponse, error)
-	// funcionalidad  Para el Cliente Administrador, para forzar el estado de un servidor (ej. marcar como DISPONIBLE o CAIDO).
+	// funcionalidad  Para el Cliente Administrador, para forzar el estado de un servidor (ej. marcar como DISPONIBLE, CAIDO o DRAINING).
 	AdminUpdateServerState(ctx context.Context, in *AdminServerUpdateRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
 	// funcionalidad para verificar la salud de un servidor si no hay actualizaciones de estado recientes.
 	PingServer(ctx context.Context, in *ServerId, opts ...grpc.CallOption) (*PingResponse, error)
+	// funcionalidad para que un servidor de juego renueve su lease de registro en el Matchmaker
+	RenewLease(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error)
+	// funcionalidad para que un servidor de juego que se detiene se dé de baja en el Matchmaker
+	DeregisterServer(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error)
+	// funcionalidades de consenso (Raft) entre las réplicas del Matchmaker
+	RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesResponse, error)
+	InstallSnapshot(ctx context.Context, in *InstallSnapshotRequest, opts ...grpc.CallOption) (*InstallSnapshotResponse, error)
 }
 
 type comunicacionServiceClient struct {
@@ -76,6 +110,66 @@ func (c *comunicacionServiceClient) GetPlayerStatus(ctx context.Context, in *Pla
 	return out, nil
 }
 
+func (c *comunicacionServiceClient) LeaveQueue(ctx context.Context, in *LeaveQueueRequest, opts ...grpc.CallOption) (*LeaveQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveQueueResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_LeaveQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) RespondReadyCheck(ctx context.Context, in *ReadyCheckRequest, opts ...grpc.CallOption) (*ReadyCheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadyCheckResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_RespondReadyCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) EstimateWaitTime(ctx context.Context, in *WaitTimeRequest, opts ...grpc.CallOption) (*WaitTimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitTimeResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_EstimateWaitTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) CreateParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_CreateParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) JoinParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_JoinParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) LeaveParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_LeaveParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *comunicacionServiceClient) AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AssignMatchResponse)
@@ -86,6 +180,16 @@ func (c *comunicacionServiceClient) AssignMatch(ctx context.Context, in *AssignM
 	return out, nil
 }
 
+func (c *comunicacionServiceClient) ReportMatchResult(ctx context.Context, in *MatchResultRequest, opts ...grpc.CallOption) (*MatchResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResultResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_ReportMatchResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *comunicacionServiceClient) UpdateServerStatus(ctx context.Context, in *ServerStatusUpdateRequest, opts ...grpc.CallOption) (*ServerStatusUpdateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ServerStatusUpdateResponse)
@@ -126,6 +230,56 @@ func (c *comunicacionServiceClient) PingServer(ctx context.Context, in *ServerId
 	return out, nil
 }
 
+func (c *comunicacionServiceClient) RenewLease(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_RenewLease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) DeregisterServer(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_DeregisterServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_RequestVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AppendEntriesResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_AppendEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *comunicacionServiceClient) InstallSnapshot(ctx context.Context, in *InstallSnapshotRequest, opts ...grpc.CallOption) (*InstallSnapshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InstallSnapshotResponse)
+	err := c.cc.Invoke(ctx, ComunicacionService_InstallSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ComunicacionServiceServer is the server API for ComunicacionService service.
 // All implementations must embed UnimplementedComunicacionServiceServer
 // for forward compatibility.
@@ -134,16 +288,38 @@ type ComunicacionServiceServer interface {
 	QueuePlayer(context.Context, *PlayerInfoRequest) (*QueuePlayerResponse, error)
 	// funcionalidad para consultar el estado actual del jugador
 	GetPlayerStatus(context.Context, *PlayerStatusRequest) (*PlayerStatusResponse, error)
+	// funcionalidad para salir de la cola (cancelar la búsqueda de partida)
+	LeaveQueue(context.Context, *LeaveQueueRequest) (*LeaveQueueResponse, error)
+	// funcionalidad para aceptar o rechazar una partida encontrada (ready-check)
+	RespondReadyCheck(context.Context, *ReadyCheckRequest) (*ReadyCheckResponse, error)
+	// funcionalidad para estimar el tiempo de espera restante en la cola
+	EstimateWaitTime(context.Context, *WaitTimeRequest) (*WaitTimeResponse, error)
+	// funcionalidad para crear un grupo (party); quien lo crea queda como líder
+	CreateParty(context.Context, *PartyRequest) (*PartyResponse, error)
+	// funcionalidad para unirse a un grupo existente
+	JoinParty(context.Context, *PartyRequest) (*PartyResponse, error)
+	// funcionalidad para salir del grupo actual
+	LeaveParty(context.Context, *PartyRequest) (*PartyResponse, error)
 	// funcionalidad de matchmaker para iniciar una partida
 	AssignMatch(context.Context, *AssignMatchRequest) (*AssignMatchResponse, error)
+	// funcionalidad para que el servidor de juego informe el resultado de una partida terminada
+	ReportMatchResult(context.Context, *MatchResultRequest) (*MatchResultResponse, error)
 	// funcionalidad para informar cambios de estado del servidor
 	UpdateServerStatus(context.Context, *ServerStatusUpdateRequest) (*ServerStatusUpdateResponse, error)
 	// funcionalidad para el Cliente Administrador. Devuelve el estado de todos los servidores y las colas de jugadores
 	AdminGetSystemStatus(context.Context, *AdminRequest) (*SystemStatusResponse, error)
-	// funcionalidad  Para el Cliente Administrador, para forzar el estado de un servidor (ej. marcar como DISPONIBLE o CAIDO).
+	// funcionalidad  Para el Cliente Administrador, para forzar el estado de un servidor (ej. marcar como DISPONIBLE, CAIDO o DRAINING).
 	AdminUpdateServerState(context.Context, *AdminServerUpdateRequest) (*AdminUpdateResponse, error)
 	// funcionalidad para verificar la salud de un servidor si no hay actualizaciones de estado recientes.
 	PingServer(context.Context, *ServerId) (*PingResponse, error)
+	// funcionalidad para que un servidor de juego renueve su lease de registro en el Matchmaker
+	RenewLease(context.Context, *LeaseRequest) (*LeaseResponse, error)
+	// funcionalidad para que un servidor de juego que se detiene se dé de baja en el Matchmaker
+	DeregisterServer(context.Context, *DeregisterRequest) (*DeregisterResponse, error)
+	// funcionalidades de consenso (Raft) entre las réplicas del Matchmaker
+	RequestVote(context.Context, *VoteRequest) (*VoteResponse, error)
+	AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesResponse, error)
+	InstallSnapshot(context.Context, *InstallSnapshotRequest) (*InstallSnapshotResponse, error)
 	mustEmbedUnimplementedComunicacionServiceServer()
 }
 
@@ -160,9 +336,30 @@ func (UnimplementedComunicacionServiceServer) QueuePlayer(context.Context, *Play
 func (UnimplementedComunicacionServiceServer) GetPlayerStatus(context.Context, *PlayerStatusRequest) (*PlayerStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerStatus not implemented")
 }
+func (UnimplementedComunicacionServiceServer) LeaveQueue(context.Context, *LeaveQueueRequest) (*LeaveQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveQueue not implemented")
+}
+func (UnimplementedComunicacionServiceServer) RespondReadyCheck(context.Context, *ReadyCheckRequest) (*ReadyCheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondReadyCheck not implemented")
+}
+func (UnimplementedComunicacionServiceServer) EstimateWaitTime(context.Context, *WaitTimeRequest) (*WaitTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateWaitTime not implemented")
+}
+func (UnimplementedComunicacionServiceServer) CreateParty(context.Context, *PartyRequest) (*PartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateParty not implemented")
+}
+func (UnimplementedComunicacionServiceServer) JoinParty(context.Context, *PartyRequest) (*PartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinParty not implemented")
+}
+func (UnimplementedComunicacionServiceServer) LeaveParty(context.Context, *PartyRequest) (*PartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveParty not implemented")
+}
 func (UnimplementedComunicacionServiceServer) AssignMatch(context.Context, *AssignMatchRequest) (*AssignMatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssignMatch not implemented")
 }
+func (UnimplementedComunicacionServiceServer) ReportMatchResult(context.Context, *MatchResultRequest) (*MatchResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportMatchResult not implemented")
+}
 func (UnimplementedComunicacionServiceServer) UpdateServerStatus(context.Context, *ServerStatusUpdateRequest) (*ServerStatusUpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateServerStatus not implemented")
 }
@@ -175,6 +372,21 @@ func (UnimplementedComunicacionServiceServer) AdminUpdateServerState(context.Con
 func (UnimplementedComunicacionServiceServer) PingServer(context.Context, *ServerId) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PingServer not implemented")
 }
+func (UnimplementedComunicacionServiceServer) RenewLease(context.Context, *LeaseRequest) (*LeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLease not implemented")
+}
+func (UnimplementedComunicacionServiceServer) DeregisterServer(context.Context, *DeregisterRequest) (*DeregisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterServer not implemented")
+}
+func (UnimplementedComunicacionServiceServer) RequestVote(context.Context, *VoteRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedComunicacionServiceServer) AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (UnimplementedComunicacionServiceServer) InstallSnapshot(context.Context, *InstallSnapshotRequest) (*InstallSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
 func (UnimplementedComunicacionServiceServer) mustEmbedUnimplementedComunicacionServiceServer() {}
 func (UnimplementedComunicacionServiceServer) testEmbeddedByValue()                             {}
 
@@ -232,6 +444,114 @@ func _ComunicacionService_GetPlayerStatus_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ComunicacionService_LeaveQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).LeaveQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_LeaveQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).LeaveQueue(ctx, req.(*LeaveQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_RespondReadyCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadyCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).RespondReadyCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_RespondReadyCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).RespondReadyCheck(ctx, req.(*ReadyCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_EstimateWaitTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).EstimateWaitTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_EstimateWaitTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).EstimateWaitTime(ctx, req.(*WaitTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_CreateParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).CreateParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_CreateParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).CreateParty(ctx, req.(*PartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_JoinParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).JoinParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_JoinParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).JoinParty(ctx, req.(*PartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_LeaveParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).LeaveParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_LeaveParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).LeaveParty(ctx, req.(*PartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ComunicacionService_AssignMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignMatchRequest)
 	if err := dec(in); err != nil {
@@ -250,6 +570,24 @@ func _ComunicacionService_AssignMatch_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ComunicacionService_ReportMatchResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).ReportMatchResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_ReportMatchResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).ReportMatchResult(ctx, req.(*MatchResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ComunicacionService_UpdateServerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ServerStatusUpdateRequest)
 	if err := dec(in); err != nil {
@@ -322,6 +660,96 @@ func _ComunicacionService_PingServer_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ComunicacionService_RenewLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).RenewLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_RenewLease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).RenewLease(ctx, req.(*LeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_DeregisterServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).DeregisterServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_DeregisterServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).DeregisterServer(ctx, req.(*DeregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_RequestVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).RequestVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_AppendEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).AppendEntries(ctx, req.(*AppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComunicacionService_InstallSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComunicacionServiceServer).InstallSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ComunicacionService_InstallSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComunicacionServiceServer).InstallSnapshot(ctx, req.(*InstallSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ComunicacionService_ServiceDesc is the grpc.ServiceDesc for ComunicacionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -337,10 +765,38 @@ var ComunicacionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPlayerStatus",
 			Handler:    _ComunicacionService_GetPlayerStatus_Handler,
 		},
+		{
+			MethodName: "LeaveQueue",
+			Handler:    _ComunicacionService_LeaveQueue_Handler,
+		},
+		{
+			MethodName: "RespondReadyCheck",
+			Handler:    _ComunicacionService_RespondReadyCheck_Handler,
+		},
+		{
+			MethodName: "EstimateWaitTime",
+			Handler:    _ComunicacionService_EstimateWaitTime_Handler,
+		},
+		{
+			MethodName: "CreateParty",
+			Handler:    _ComunicacionService_CreateParty_Handler,
+		},
+		{
+			MethodName: "JoinParty",
+			Handler:    _ComunicacionService_JoinParty_Handler,
+		},
+		{
+			MethodName: "LeaveParty",
+			Handler:    _ComunicacionService_LeaveParty_Handler,
+		},
 		{
 			MethodName: "AssignMatch",
 			Handler:    _ComunicacionService_AssignMatch_Handler,
 		},
+		{
+			MethodName: "ReportMatchResult",
+			Handler:    _ComunicacionService_ReportMatchResult_Handler,
+		},
 		{
 			MethodName: "UpdateServerStatus",
 			Handler:    _ComunicacionService_UpdateServerStatus_Handler,
@@ -357,6 +813,26 @@ var ComunicacionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PingServer",
 			Handler:    _ComunicacionService_PingServer_Handler,
 		},
+		{
+			MethodName: "RenewLease",
+			Handler:    _ComunicacionService_RenewLease_Handler,
+		},
+		{
+			MethodName: "DeregisterServer",
+			Handler:    _ComunicacionService_DeregisterServer_Handler,
+		},
+		{
+			MethodName: "RequestVote",
+			Handler:    _ComunicacionService_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _ComunicacionService_AppendEntries_Handler,
+		},
+		{
+			MethodName: "InstallSnapshot",
+			Handler:    _ComunicacionService_InstallSnapshot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "comunicacion.proto",
@@ -0,0 +1,49 @@
+package reloj
+
+import pb "MV3/proto/grpc-server/proto"
+
+// Reloj recibido en un mensaje. Un mensaje sin reloj trae un reloj vacío.
+func DesdeProto(vc *pb.VectorClock) Reloj {
+	return Reloj(vc.GetClocks()).Copia()
+}
+
+// Marca HLC recibida en un mensaje. Un mensaje sin marca trae la marca cero,
+// que no adelanta el reloj de quien la recibe.
+func MarcaDesdeProto(vc *pb.VectorClock) Marca {
+	h := vc.GetHybridClock()
+	return Marca{Fisico: h.GetPhysicalMs(), Logico: h.GetLogical()}
+}
+
+// Marca para enviarla en un mensaje.
+func (m Marca) Proto() *pb.HybridClock {
+	return &pb.HybridClock{PhysicalMs: m.Fisico, Logical: m.Logico}
+}
+
+// Copia del reloj para enviarla en un mensaje. gRPC serializa el mensaje
+// fuera del control de quien lo arma, así que nunca debe llevar el mapa vivo.
+func (r Reloj) Proto() *pb.VectorClock {
+	return &pb.VectorClock{Clocks: r.Copia()}
+}
+
+// Envío de un mensaje. Devuelve el reloj que debe llevar, con la marca HLC
+// del envío.
+func (p *Proceso) EnviarMensaje(evento string) *pb.VectorClock {
+	r, marca := p.avanzar(Envio, evento, nil, Marca{})
+	return &pb.VectorClock{Clocks: r, HybridClock: marca.Proto()}
+}
+
+// Recepción de un mensaje: primero se olvidan los procesos que el remitente
+// informa como podados y después se incorporan su reloj y su marca HLC.
+func (p *Proceso) RecibirMensaje(evento string, vc *pb.VectorClock) Reloj {
+	p.Olvidar(vc.GetPruned()...)
+	r, _ := p.avanzar(Recepcion, evento, DesdeProto(vc), MarcaDesdeProto(vc))
+	return r
+}
+
+// Copia del valor actual para enviarla en un mensaje, sin registrar un
+// evento: la respuesta de una RPC lleva el reloj y la marca de su recepción.
+func (p *Proceso) Proto() *pb.VectorClock {
+	vc := p.Copia().Proto()
+	vc.HybridClock = p.hlc.Actual().Proto()
+	return vc
+}
@@ -2,31 +2,41 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"net"
+	"os"
+	"sync"
 	"time"
 
 	pb "MV3/proto/grpc-server/proto"
+	"MV3/reloj"
 
 	"google.golang.org/grpc"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 const (
-	serverID       = "GameServer3"
-	serverAddr     = "localhost:60053"
-	matchmakerAddr = "localhost:50051"
+	serverID   = "GameServer3"
+	serverAddr = "localhost:60053"
+
+	// Capacidad relativa del servidor: el Matchmaker le asigna más partidas
+	// cuanto mayor sea, si usa la selección ponderada por capacidad
+	capacidad = 3
+
+	// Reintentos para informar el resultado de una partida al Matchmaker
+	intentosReporte = 5
+	esperaReporte   = 2 * time.Second
 )
 
 var (
+	// Estado del servidor. Lo cambian la partida, AssignMatch y el drenaje
+	// desde goroutines distintas: se lee con estadoActual
+	muEstado    sync.Mutex
 	status      = "DISPONIBLE"
-	vectorClock = map[string]int32{
-		"GameServer3": 0,
-		"Matchmaker":  0,
-		"Player1":     0,
-		"Player2":     0,
-	}
+	vectorClock *reloj.Proceso
 )
 
 type gameServer struct {
@@ -34,26 +44,65 @@ type gameServer struct {
 }
 
 func main() {
+	intervalo := flag.Duration("renovacion", 3*time.Second, "intervalo de renovación del lease en el Matchmaker")
+	eventos := flag.String("eventos", "eventos_gameserver3.jsonl", "registro causal de eventos (vacío para no registrarlos)")
+	flag.Parse()
+
+	registro, err := reloj.AbrirRegistro(serverID, *eventos)
+	if err != nil {
+		log.Fatalf("[GameServer3] No se pudo abrir el registro de eventos: %v", err)
+	}
+	defer registro.Cerrar()
+	vectorClock = reloj.NuevoProceso(serverID, registro)
+	// Cada línea del log lleva la marca HLC del último evento del proceso
+	log.SetOutput(vectorClock.Hibrido().Escritor(os.Stderr))
+
 	lis, err := net.Listen("tcp", serverAddr)
 	if err != nil {
 		log.Fatalf("[GameServer3] No se pudo escuchar en %s: %v", serverAddr, err)
 	}
-	s := grpc.NewServer()
+	s := grpc.NewServer(grpc.UnaryInterceptor(deduplicarLlamadas))
 	pb.RegisterComunicacionServiceServer(s, &gameServer{})
 
-	go registrarConMatchmaker()
+	go registrarConMatchmaker(*intervalo)
+
+	listo := make(chan struct{})
+	go esperarRetiro(s, listo)
 
 	fmt.Printf("[GameServer3] Servidor escuchando en %s\n", serverAddr)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("[GameServer3] Fallo al servir: %v", err)
 	}
+	<-listo
 }
 
 func (gs *gameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
-	fmt.Printf("[GameServer3] Recibida asignación de partida: %d para jugadores %v\n", req.MatchId, req.PlayersIds)
+	if err := admitirPartida(); err != nil {
+		return nil, err
+	}
+	vectorClock.RecibirMensaje(fmt.Sprintf("AssignMatch de la partida %d", req.MatchId), req.VectorClock)
+	fmt.Printf("[GameServer3] Recibida asignación de partida: %d (%s)\n", req.MatchId, req.GameMode)
+	var jugadores []int32
+	for _, team := range req.Teams {
+		fmt.Printf("[GameServer3]   Equipo %d: jugadores %v\n", team.TeamId, team.PlayersIds)
+		jugadores = append(jugadores, team.PlayersIds...)
+	}
 	cambiarEstado("OCUPADO")
+	go jugarPartida(req)
+
+	return &pb.AssignMatchResponse{
+		Message:            "Partida recibida",
+		MatchId:            req.MatchId,
+		PlayersIds:         jugadores,
+		MatchServerAddress: serverAddr,
+		VectorClock:        vectorClock.Proto(),
+	}, nil
+}
+
+func jugarPartida(req *pb.AssignMatchRequest) {
 	actualizarEstadoEnMatchmaker("OCUPADO")
 
+	inicio := time.Now()
 	duracion := rand.Intn(11) + 10
 	fmt.Printf("[GameServer3] Simulando partida por %d segundos...\n", duracion)
 	time.Sleep(time.Duration(duracion) * time.Second)
@@ -62,46 +111,80 @@ func (gs *gameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchReques
 		fmt.Println("[GameServer3] ¡Simulando caída del servidor!")
 		cambiarEstado("CAIDO")
 		actualizarEstadoEnMatchmaker("CAIDO")
+		partidas.Done()
 		select {} // Simula caída
 	}
 
-	cambiarEstado("DISPONIBLE")
-	actualizarEstadoEnMatchmaker("DISPONIBLE")
+	var ganador int32
+	if len(req.Teams) > 0 {
+		ganador = req.Teams[rand.Intn(len(req.Teams))].TeamId
+	}
+	fmt.Printf("[GameServer3] Partida %d terminada. Equipo ganador: %d\n", req.MatchId, ganador)
 
-	return &pb.AssignMatchResponse{
-		Message:            "Partida finalizada",
-		MatchId:            req.MatchId,
-		PlayersIds:         req.PlayersIds,
-		MatchServerAddress: serverAddr,
-		VectorClock:        &pb.VectorClock{Clocks: vectorClock},
+	estadoFinal := "DISPONIBLE"
+	if retirandose() {
+		estadoFinal = "DRAINING"
+	}
+	cambiarEstado(estadoFinal)
+	informarResultado(req.MatchId, ganador, time.Since(inicio))
+	actualizarEstadoEnMatchmaker(estadoFinal)
+	terminarPartida()
+}
+
+func (gs *gameServer) PingServer(ctx context.Context, req *pb.ServerId) (*pb.PingResponse, error) {
+	// Un servidor caído no responde (igual que en la simulación de caída):
+	// la llamada espera a que venza el plazo de quien hace el ping, para no
+	// dejar una goroutine bloqueada por cada ping
+	estado := estadoActual()
+	if estado == "CAIDO" {
+		<-ctx.Done()
+		return nil, grpcstatus.FromContextError(ctx.Err()).Err()
+	}
+	vectorClock.RecibirMensaje("PingServer del Matchmaker", req.VectorClock)
+	return &pb.PingResponse{
+		Status:      estado,
+		Message:     serverID + " activo",
+		VectorClock: vectorClock.Proto(),
 	}, nil
 }
 
+// Estado actual del servidor
+func estadoActual() string {
+	muEstado.Lock()
+	defer muEstado.Unlock()
+	return status
+}
+
 func cambiarEstado(nuevo string) {
+	muEstado.Lock()
 	status = nuevo
-	vectorClock[serverID]++
-	log.Printf("[GameServer3] Estado cambiado a %s. VC: %+v\n", nuevo, vectorClock)
+	muEstado.Unlock()
+	vc := vectorClock.Local("Estado " + nuevo)
+	log.Printf("[GameServer3] Estado cambiado a %s. VC: %+v\n", nuevo, vc)
 }
 
-func registrarConMatchmaker() {
+func registrarConMatchmaker(intervalo time.Duration) {
 	log.Println("[GameServer3] Registrando con el Matchmaker...")
 	actualizarEstadoEnMatchmaker("DISPONIBLE")
+
+	// Desde aquí el servidor mantiene su registro renovando el lease
+	renovarLease(intervalo)
 }
 
 func actualizarEstadoEnMatchmaker(nuevoEstado string) {
-	conn, err := grpc.Dial(matchmakerAddr, grpc.WithInsecure())
+	conn, err := conectarMatchmaker()
 	if err != nil {
 		log.Printf("[GameServer3] No se pudo conectar al Matchmaker: %v", err)
 		return
 	}
-	defer conn.Close()
 
 	client := pb.NewComunicacionServiceClient(conn)
 	req := &pb.ServerStatusUpdateRequest{
 		ServerId:    serverID,
 		NewStatus:   nuevoEstado,
 		Address:     serverAddr,
-		VectorClock: &pb.VectorClock{Clocks: vectorClock},
+		VectorClock: vectorClock.EnviarMensaje("UpdateServerStatus " + nuevoEstado),
+		Capacity:    capacidad,
 	}
 
 	res, err := client.UpdateServerStatus(context.Background(), req)
@@ -109,5 +192,75 @@ func actualizarEstadoEnMatchmaker(nuevoEstado string) {
 		log.Printf("[GameServer3] Error al actualizar estado: %v", err)
 	} else {
 		log.Printf("[GameServer3] Estado actualizado: %s", res.StatusCode)
+		vectorClock.RecibirMensaje("Respuesta de UpdateServerStatus", res.VectorClock)
+	}
+}
+
+func informarResultado(matchID, ganador int32, duracion time.Duration) {
+	// La misma clave en todos los intentos: el resultado se registra una vez
+	ctx := conIdempotencia(context.Background())
+	for intento := 1; intento <= intentosReporte; intento++ {
+		conn, err := conectarMatchmaker()
+		if err != nil {
+			log.Printf("[GameServer3] No se pudo conectar al Matchmaker: %v", err)
+			time.Sleep(esperaReporte)
+			continue
+		}
+		client := pb.NewComunicacionServiceClient(conn)
+		res, err := client.ReportMatchResult(ctx, &pb.MatchResultRequest{
+			MatchId:       matchID,
+			ServerId:      serverID,
+			WinningTeamId: ganador,
+			DurationMs:    duracion.Milliseconds(),
+			VectorClock:   vectorClock.EnviarMensaje(fmt.Sprintf("ReportMatchResult de la partida %d", matchID)),
+		})
+
+		if err == nil {
+			vectorClock.RecibirMensaje("Respuesta de ReportMatchResult", res.VectorClock)
+			log.Printf("[GameServer3] Resultado de la partida %d informado: %s (%s)", matchID, res.StatusCode, res.Message)
+			return
+		}
+		log.Printf("[GameServer3] Error al informar el resultado de la partida %d (intento %d/%d): %v", matchID, intento, intentosReporte, err)
+		time.Sleep(esperaReporte)
+	}
+}
+
+func renovarLease(intervalo time.Duration) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if estadoActual() == "CAIDO" {
+			continue
+		}
+
+		conn, err := conectarMatchmaker()
+		if err != nil {
+			log.Printf("[GameServer3] No se pudo conectar al Matchmaker: %v", err)
+			continue
+		}
+		client := pb.NewComunicacionServiceClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), intervalo)
+		res, err := client.RenewLease(ctx, &pb.LeaseRequest{
+			ServerId:        serverID,
+			RenewIntervalMs: int32(intervalo / time.Millisecond),
+			VectorClock:     vectorClock.EnviarMensaje("RenewLease"),
+		})
+		cancel()
+
+		if err != nil {
+			log.Printf("[GameServer3] Error al renovar el lease: %v", err)
+			continue
+		}
+		vectorClock.RecibirMensaje("Respuesta de RenewLease", res.VectorClock)
+		if res.Drain {
+			pedirDrenaje("pedido del administrador")
+		}
+		// El Matchmaker no conoce al servidor (por ejemplo, porque se reinició).
+		// Un servidor que se está retirando no vuelve a registrarse
+		if res.StatusCode != "SUCCESS" && !retirandose() {
+			log.Println("[GameServer3] Lease rechazado. Registrando de nuevo en el Matchmaker...")
+			actualizarEstadoEnMatchmaker(estadoActual())
+		}
 	}
 }
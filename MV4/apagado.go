@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+)
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Espera SIGINT o SIGTERM y apaga la réplica. Cierra listo al terminar. Una
+// segunda señal corta el apagado.
+func (s *server) esperarApagado(g *grpc.Server, listo chan<- struct{}) {
+	señales := make(chan os.Signal, 2)
+	signal.Notify(señales, syscall.SIGINT, syscall.SIGTERM)
+	<-señales
+	go func() {
+		<-señales
+		log.Printf("[Matchmaker] Segunda señal: apagado inmediato")
+		os.Exit(1)
+	}()
+	s.apagar(g)
+	close(listo)
+}
+
+// Apaga la réplica en orden: deja de aceptar jugadores en la cola, termina
+// las llamadas en curso, confirma los últimos cambios y guarda el estado en
+// disco.
+func (s *server) apagar(g *grpc.Server) {
+	log.Printf("[Matchmaker] Apagando la réplica %s: no se aceptan más jugadores en la cola", s.raft.id)
+	s.cerrando.Store(true)
+	g.GracefulStop()
+
+	// Lo que cambie después (asignaciones o pings en curso) ya no se propone
+	s.ejecutar(func() { s.sirviendo.Store(false) })
+	if s.raft.esLider() {
+		if err := s.raft.esperarCommit(s.raft.ultimaEntrada()); err != nil {
+			log.Printf("[Matchmaker] Cambios sin confirmar al apagar: %v", err)
+		}
+	}
+
+	s.raft.detener()
+	s.eventos.Cerrar()
+	log.Printf("[Matchmaker] Réplica %s apagada", s.raft.id)
+}
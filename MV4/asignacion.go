@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Parámetros del envío de partidas a los servidores de juego
+const (
+	// Tiempo máximo para que un servidor confirme que recibió una partida
+	assignTimeout = 5 * time.Second
+	// Intentos contra el mismo servidor cuando el error es transitorio. Entre
+	// intentos se espera assignBackoff, duplicándolo cada vez
+	assignIntentos = 3
+	assignBackoff  = 200 * time.Millisecond
+	// Servidores a los que se puede pasar una partida antes de cancelarla
+	assignFailoverMax = 2
+)
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Envía AssignMatch al servidor, reintentando los errores transitorios con
+// backoff exponencial. Devuelve la confirmación del servidor, o el último
+// error si no confirmó.
+func (s *server) asignarConReintentos(id, addr string, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	client, err := s.conexiones.cliente(id, addr)
+	if err != nil {
+		return nil, err
+	}
+	espera := assignBackoff
+	for intento := 1; ; intento++ {
+		ctx, cancel := context.WithTimeout(contextoAsignacion(context.Background(), req, id), assignTimeout)
+		res, err := client.AssignMatch(ctx, req)
+		cancel()
+		if err == nil || !errorTransitorio(err) || intento == assignIntentos {
+			return res, err
+		}
+		log.Printf("[Matchmaker] Error transitorio asignando la partida %d en %s (intento %d/%d): %v. Reintentando en %v",
+			req.MatchId, id, intento, assignIntentos, err, espera)
+		time.Sleep(espera)
+		espera *= 2
+	}
+}
+
+// Indica si vale la pena reintentar: el servidor no respondió a tiempo o no
+// se pudo conectar en ese momento. Cualquier otro error (el servidor rechazó
+// la llamada o no la implementa) se considera definitivo.
+func errorTransitorio(err error) bool {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// Motivo con que un servidor rechazó una partida (DRAINING u OCUPADO), si lo
+// informó en los detalles del error.
+func motivoRechazo(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+// El servidor no confirmó la partida. La partida pasa de inmediato a otro
+// servidor disponible; si no hay ninguno (o ya falló en demasiados), se
+// cancela y los jugadores vuelven a la cabeza de la cola.
+//
+// Un error definitivo saca al servidor de la rotación marcándolo CAIDO. Tras
+// errores transitorios el servidor no se descarta: se le hace un ping y vuelve
+// a quedar DISPONIBLE si responde.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) fallarAsignacion(gs *GameServerInfo, match *Match, entries []*queueEntry, fallos int, err error) {
+	s.registrarEvento(reloj.Local, fmt.Sprintf("%s no confirmó la partida %d", gs.ID, match.ID), nil)
+	s.marcarServidor(gs.ID)
+	s.marcarPartida(match.ID)
+
+	// El reemplazo se elige mientras el servidor que falló sigue OCUPADO,
+	// para no volver a elegirlo
+	var nuevo *GameServerInfo
+	if fallos < assignFailoverMax {
+		nuevo = s.elegirServidor()
+	}
+	if nuevo != nil {
+		log.Printf("[Matchmaker] %s no confirmó la partida %d (%v). Reasignada a %s", gs.ID, match.ID, err, nuevo.ID)
+		if s.serverMatch[gs.ID] == match.ID {
+			delete(s.serverMatch, gs.ID)
+		}
+		s.asignadas[gs.ID]--
+		match.ServerID = nuevo.ID
+		match.ServerAddress = nuevo.Address
+		s.serverMatch[nuevo.ID] = match.ID
+		s.asignadas[nuevo.ID]++
+		nuevo.Status = "OCUPADO"
+		s.marcarServidor(nuevo.ID)
+		s.enviarAssignMatch(nuevo, match, entries, fallos+1)
+	} else {
+		log.Printf("[Matchmaker] %s no confirmó la partida %d (%v) y no hay otro servidor disponible. Los jugadores vuelven a la cola",
+			gs.ID, match.ID, err)
+		s.cerrarPartida(match, "CANCELADA")
+
+		// Los jugadores que cancelaron durante la asignación no vuelven a la cola
+		s.devolverACola(match.Mode, entries, s.cancelados)
+		for _, id := range match.jugadores() {
+			delete(s.cancelados, id)
+		}
+		s.marcar("meta")
+	}
+
+	// UpdateServerStatus pudo reemplazar el registro del servidor mientras
+	// tanto: se actualiza el actual
+	actual, ok := s.gameServers[gs.ID]
+	if !ok {
+		return
+	}
+	switch {
+	case motivoRechazo(err) == "OCUPADO":
+		// El servidor sigue jugando otra partida: informará DISPONIBLE al
+		// terminarla
+		log.Printf("[Matchmaker] %s todavía está jugando otra partida. Marcado como OCUPADO", actual.ID)
+		actual.Status = "OCUPADO"
+		return
+	case status.Code(err) == codes.FailedPrecondition || actual.Status == "DRAINING":
+		// El servidor rechaza partidas porque se está retirando: no está caído
+		log.Printf("[Matchmaker] %s se está retirando. Marcado como DRAINING", actual.ID)
+		actual.Status = "DRAINING"
+		return
+	case !errorTransitorio(err):
+		log.Printf("[Matchmaker] Error definitivo de %s. Marcado como CAIDO", actual.ID)
+		actual.Status = "CAIDO"
+		return
+	}
+	go s.verificarTrasFallo(actual.ID, actual.Address)
+}
+
+// Hace ping a un servidor que no confirmó una partida por un error
+// transitorio. Si responde DISPONIBLE, vuelve a la rotación; si responde con
+// otro estado, queda con ese estado; si no responde, se marca CAIDO como en la
+// verificación de salud.
+func (s *server) verificarTrasFallo(id, addr string) {
+	res, err := s.pingServidor(id, addr)
+	s.ejecutar(func() {
+		gs, ok := s.gameServers[id]
+		if !ok {
+			return
+		}
+		if err == nil && gs.Status == "OCUPADO" && s.serverMatch[id] == 0 {
+			gs.LastUpdate = time.Now()
+			gs.HLC = s.hlc.Actual()
+			s.marcarServidor(id)
+			if res.Status != "DISPONIBLE" {
+				// Responde pero no está libre (puede haber empezado la partida
+				// aunque la confirmación no llegó): queda con el estado que
+				// informa
+				log.Printf("[Matchmaker] %s responde al ping después del error pero informa %s", id, res.Status)
+				s.registrarEvento(reloj.Local, id+" responde al ping después del error: "+res.Status, nil)
+				if res.Status == "DRAINING" {
+					gs.Status = "DRAINING"
+				}
+				return
+			}
+			log.Printf("[Matchmaker] %s responde al ping después del error. Sigue DISPONIBLE", id)
+			s.registrarEvento(reloj.Local, id+" responde al ping después del error", nil)
+			gs.Status = "DISPONIBLE"
+			s.despertarMatchmaking()
+			return
+		}
+		s.aplicarPing(id, res, err)
+	})
+}
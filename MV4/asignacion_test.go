@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Servidor de juego de prueba: informa la clave de idempotencia de cada
+// AssignMatch que recibe. El primero no lo confirma nunca (la llamada queda
+// esperando hasta que vence), como un servidor que recibió la partida pero
+// cuya confirmación no llegó al líder.
+type servidorFalso struct {
+	pb.UnimplementedComunicacionServiceServer
+	claves   chan string
+	llamadas atomic.Int32
+	ping     atomic.Pointer[pb.ServerId] // último ping recibido
+}
+
+func (f *servidorFalso) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	clave := ""
+	if v := md.Get(claveIdempotencia); len(v) > 0 {
+		clave = v[0]
+	}
+	f.claves <- clave
+	if f.llamadas.Add(1) == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &pb.AssignMatchResponse{MatchId: req.MatchId}, nil
+}
+
+func (f *servidorFalso) PingServer(ctx context.Context, req *pb.ServerId) (*pb.PingResponse, error) {
+	f.ping.Store(req)
+	return &pb.PingResponse{
+		Status:      "OCUPADO",
+		VectorClock: &pb.VectorClock{Clocks: map[string]int32{"GameServer1": 7}, HybridClock: &pb.HybridClock{PhysicalMs: 1}},
+	}, nil
+}
+
+func iniciarServidorFalso(t *testing.T) (*servidorFalso, string) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error al escuchar: %v", err)
+	}
+	f := &servidorFalso{claves: make(chan string, 10)}
+	g := grpc.NewServer()
+	pb.RegisterComunicacionServiceServer(g, f)
+	go g.Serve(lis)
+	t.Cleanup(g.Stop)
+	return f, lis.Addr().String()
+}
+
+// Una partida que el servidor no llegó a confirmar antes del cambio de líder
+// sigue en curso: el nuevo líder se la reenvía con la misma clave de
+// idempotencia en lugar de cancelarla.
+func TestPartidaSinConfirmarSeReenviaAlCambiarDeLider(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	for id := range c.peers {
+		c.arrancar(id)
+	}
+	liderID, _ := c.esperarLider()
+	falso, addr := iniciarServidorFalso(t)
+
+	ctx := contexto(t)
+	cli := c.cliente(liderID)
+	if _, err := cli.UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{ServerId: "GameServer1", NewStatus: "DISPONIBLE", Address: addr}); err != nil {
+		t.Fatalf("UpdateServerStatus: %v", err)
+	}
+	for _, jugador := range []int32{1, 2} {
+		if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: jugador}); err != nil {
+			t.Fatalf("QueuePlayer(%d): %v", jugador, err)
+		}
+	}
+
+	var matchID int32
+	for limite := time.Now().Add(esperaPrueba); matchID == 0; time.Sleep(20 * time.Millisecond) {
+		if time.Now().After(limite) {
+			t.Fatal("No se formó la partida")
+		}
+		res, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: 1})
+		if err != nil {
+			t.Fatalf("GetPlayerStatus: %v", err)
+		}
+		if res.Status == "MATCH FOUND" {
+			matchID = res.MatchId
+		}
+	}
+	for _, jugador := range []int32{1, 2} {
+		if _, err := cli.RespondReadyCheck(ctx, &pb.ReadyCheckRequest{PlayerId: jugador, MatchId: matchID, Accept: true}); err != nil {
+			t.Fatalf("RespondReadyCheck(%d): %v", jugador, err)
+		}
+	}
+
+	var clave string
+	select {
+	case clave = <-falso.claves:
+	case <-time.After(esperaPrueba):
+		t.Fatal("El servidor no recibió la partida")
+	}
+	c.detener(liderID)
+
+	nuevoID, _ := c.esperarLider()
+	select {
+	case reenvio := <-falso.claves:
+		if reenvio != clave {
+			t.Errorf("El nuevo líder reenvió la partida con la clave %q, se esperaba %q", reenvio, clave)
+		}
+	case <-time.After(esperaPrueba):
+		t.Fatal("El nuevo líder no reenvió la partida")
+	}
+
+	nuevo := c.replicas[nuevoID].srv
+	for limite := time.Now().Add(esperaPrueba); ; time.Sleep(20 * time.Millisecond) {
+		var estado string
+		var recibida bool
+		nuevo.ejecutar(func() {
+			if m, ok := nuevo.matches[matchID]; ok {
+				estado, recibida = m.Status, m.Recibida
+			}
+		})
+		if estado == "EN CURSO" && recibida {
+			return
+		}
+		if estado != "EN CURSO" || time.Now().After(limite) {
+			t.Fatalf("La partida %d quedó %s (recibida: %v), se esperaba EN CURSO y recibida", matchID, estado, recibida)
+		}
+	}
+}
+
+// Un servidor que no confirmó la partida pero responde al ping como OCUPADO
+// (pudo haberla empezado) no vuelve a la rotación.
+func TestServidorOcupadoTrasFalloNoVuelveALaRotacion(t *testing.T) {
+	c := nuevoClusterPrueba(t, 1)
+	srv := c.arrancar("1")
+	c.esperarLider()
+	_, addr := iniciarServidorFalso(t)
+
+	ctx := contexto(t)
+	if _, err := c.cliente("1").UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{ServerId: "GameServer1", NewStatus: "DISPONIBLE", Address: addr}); err != nil {
+		t.Fatalf("UpdateServerStatus: %v", err)
+	}
+	// Como tras un AssignMatch que venció sin confirmarse
+	srv.ejecutar(func() {
+		srv.gameServers["GameServer1"].Status = "OCUPADO"
+		srv.marcarServidor("GameServer1")
+	})
+
+	srv.verificarTrasFallo("GameServer1", addr)
+	var estado string
+	srv.ejecutar(func() { estado = srv.gameServers["GameServer1"].Status })
+	if estado != "OCUPADO" {
+		t.Errorf("El servidor quedó %s, se esperaba OCUPADO", estado)
+	}
+}
+
+// El ping a un servidor de juego lleva los relojes del Matchmaker, y el
+// Matchmaker incorpora los de la respuesta.
+func TestPingLlevaLosRelojes(t *testing.T) {
+	c := nuevoClusterPrueba(t, 1)
+	srv := c.arrancar("1")
+	c.esperarLider()
+	falso, addr := iniciarServidorFalso(t)
+
+	if _, err := c.cliente("1").UpdateServerStatus(contexto(t), &pb.ServerStatusUpdateRequest{ServerId: "GameServer1", NewStatus: "DISPONIBLE", Address: addr}); err != nil {
+		t.Fatalf("UpdateServerStatus: %v", err)
+	}
+	if _, err := srv.pingServidor("GameServer1", addr); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+
+	req := falso.ping.Load()
+	if req.GetVectorClock().GetClocks()["Matchmaker"] == 0 || req.GetVectorClock().GetHybridClock().GetPhysicalMs() == 0 {
+		t.Errorf("El ping llegó sin los relojes del Matchmaker: %v", req.GetVectorClock())
+	}
+	var recibido int32
+	srv.ejecutar(func() { recibido = srv.vectorClock["GameServer1"] })
+	if recibido < 7 {
+		t.Errorf("El Matchmaker tiene GameServer1 en %d tras la respuesta, se esperaba al menos 7", recibido)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+)
+
+// Orden para el bucle de eventos: una función que lee o modifica el estado del
+// Matchmaker. listo se cierra cuando terminó y sus cambios ya se propusieron.
+// Si la orden es parte de una llamada, en propuesta queda el índice de la
+// entrada del log con sus cambios.
+type orden struct {
+	fn        func()
+	listo     chan struct{}
+	propuesta *atomic.Int64
+}
+
+// Clave del contexto de una llamada con la entrada del log que la llamada
+// debe esperar antes de responder (ver interceptorLider).
+type clavePropuesta struct{}
+
+// ===================== BUCLE DE EVENTOS =========================
+
+// Único dueño del estado del Matchmaker. Ejecuta de a una las órdenes de las
+// RPCs y de las tareas de fondo, y las pasadas de emparejamiento: cada vez que
+// se le avisa de un cambio (un jugador entra a la cola, un servidor queda
+// disponible o el administrador fuerza un estado) y cada ventanaInterval,
+// porque las ventanas de habilidad se amplían con la espera aunque no haya
+// avisos. Después de cada paso propone al cluster los cambios que hizo.
+func (s *server) bucleEventos() {
+	ticker := time.NewTicker(ventanaInterval)
+	defer ticker.Stop()
+
+	for {
+		var o orden
+		select {
+		case o = <-s.ordenes:
+			o.fn()
+		case <-s.avisos:
+			s.pasadaEmparejamiento()
+		case <-ticker.C:
+			s.pasadaEmparejamiento()
+		}
+		indice := s.proponerCambios()
+		if o.propuesta != nil && indice > 0 {
+			o.propuesta.Store(indice)
+		}
+		if o.listo != nil {
+			close(o.listo)
+		}
+	}
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Ejecuta fn en el bucle de eventos y espera a que termine. No puede llamarse
+// desde el bucle: una orden que necesita trabajo asíncrono lanza una
+// goroutine, y esa goroutine vuelve al bucle con ejecutar.
+func (s *server) ejecutar(fn func()) {
+	s.ejecutarEn(context.Background(), fn)
+}
+
+// Como ejecutar, para una orden que es parte de la llamada de ctx: si la
+// orden propone cambios, la llamada espera a que se confirmen.
+func (s *server) ejecutarEn(ctx context.Context, fn func()) {
+	propuesta, _ := ctx.Value(clavePropuesta{}).(*atomic.Int64)
+	listo := make(chan struct{})
+	s.ordenes <- orden{fn: fn, listo: listo, propuesta: propuesta}
+	<-listo
+}
+
+// Hace que la llamada de ctx espere, antes de responder, a que se confirme la
+// entrada del log del índice indicado.
+func esperarEntrada(ctx context.Context, indice int64) {
+	if propuesta, ok := ctx.Value(clavePropuesta{}).(*atomic.Int64); ok && indice > propuesta.Load() {
+		propuesta.Store(indice)
+	}
+}
+
+// Ejecuta en el bucle un comando con la forma de una RPC y devuelve su
+// respuesta.
+func comando[Req, Res any](s *server, h func(context.Context, Req) (Res, error), ctx context.Context, req Req) (res Res, err error) {
+	s.ejecutarEn(ctx, func() { res, err = h(ctx, req) })
+	return res, err
+}
+
+// Avisa al bucle de eventos que hay cambios para emparejar. No bloquea: si ya
+// hay un aviso pendiente, la próxima pasada también verá este cambio.
+func (s *server) despertarMatchmaking() {
+	select {
+	case s.avisos <- struct{}{}:
+	default:
+	}
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) pasadaEmparejamiento() {
+	if s.sirviendo.Load() && !s.cerrando.Load() {
+		s.emparejar()
+	}
+}
+
+// ===================== RPCS =========================
+
+// Atiende las RPCs. Cada llamada se convierte en una orden para el bucle de
+// eventos, salvo las de Raft (ver raft.go) y el ping del administrador, que
+// espera al servidor de juego fuera del bucle.
+type servicio struct {
+	pb.UnimplementedComunicacionServiceServer
+	s *server
+}
+
+func (v *servicio) QueuePlayer(ctx context.Context, req *pb.PlayerInfoRequest) (*pb.QueuePlayerResponse, error) {
+	return comando(v.s, v.s.QueuePlayer, ctx, req)
+}
+
+func (v *servicio) GetPlayerStatus(ctx context.Context, req *pb.PlayerStatusRequest) (*pb.PlayerStatusResponse, error) {
+	return comando(v.s, v.s.GetPlayerStatus, ctx, req)
+}
+
+func (v *servicio) LeaveQueue(ctx context.Context, req *pb.LeaveQueueRequest) (*pb.LeaveQueueResponse, error) {
+	return comando(v.s, v.s.LeaveQueue, ctx, req)
+}
+
+func (v *servicio) RespondReadyCheck(ctx context.Context, req *pb.ReadyCheckRequest) (*pb.ReadyCheckResponse, error) {
+	return comando(v.s, v.s.RespondReadyCheck, ctx, req)
+}
+
+func (v *servicio) EstimateWaitTime(ctx context.Context, req *pb.WaitTimeRequest) (*pb.WaitTimeResponse, error) {
+	return comando(v.s, v.s.EstimateWaitTime, ctx, req)
+}
+
+func (v *servicio) CreateParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	return comando(v.s, v.s.CreateParty, ctx, req)
+}
+
+func (v *servicio) JoinParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	return comando(v.s, v.s.JoinParty, ctx, req)
+}
+
+func (v *servicio) LeaveParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	return comando(v.s, v.s.LeaveParty, ctx, req)
+}
+
+func (v *servicio) ReportMatchResult(ctx context.Context, req *pb.MatchResultRequest) (*pb.MatchResultResponse, error) {
+	return comando(v.s, v.s.ReportMatchResult, ctx, req)
+}
+
+func (v *servicio) UpdateServerStatus(ctx context.Context, req *pb.ServerStatusUpdateRequest) (*pb.ServerStatusUpdateResponse, error) {
+	return comando(v.s, v.s.UpdateServerStatus, ctx, req)
+}
+
+func (v *servicio) AdminGetSystemStatus(ctx context.Context, req *pb.AdminRequest) (*pb.SystemStatusResponse, error) {
+	return comando(v.s, v.s.AdminGetSystemStatus, ctx, req)
+}
+
+func (v *servicio) AdminUpdateServerState(ctx context.Context, req *pb.AdminServerUpdateRequest) (*pb.AdminUpdateResponse, error) {
+	return comando(v.s, v.s.AdminUpdateServerState, ctx, req)
+}
+
+func (v *servicio) RenewLease(ctx context.Context, req *pb.LeaseRequest) (*pb.LeaseResponse, error) {
+	return comando(v.s, v.s.RenewLease, ctx, req)
+}
+
+func (v *servicio) DeregisterServer(ctx context.Context, req *pb.DeregisterRequest) (*pb.DeregisterResponse, error) {
+	return comando(v.s, v.s.DeregisterServer, ctx, req)
+}
+
+func (v *servicio) PingServer(ctx context.Context, req *pb.ServerId) (*pb.PingResponse, error) {
+	return v.s.PingServer(ctx, req)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,14 +11,12 @@ import (
 	"time"
 
 	pb "cliente/proto/grpc-server/proto"
-
-	"google.golang.org/grpc"
 )
 
-const matchmakerAddress = "localhost:50051"
-
 func main() {
-	conn, err := grpc.Dial(matchmakerAddress, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(5*time.Second))
+	flag.Parse()
+
+	conn, err := conectarMatchmaker()
 	if err != nil {
 		log.Fatalf("No se pudo conectar al Matchmaker: %v", err)
 	}
@@ -31,7 +30,8 @@ func main() {
 		fmt.Println("\n--- Menú Administrador ---")
 		fmt.Println("1. Ver estado del sistema")
 		fmt.Println("2. Forzar estado de servidor")
-		fmt.Println("3. Salir")
+		fmt.Println("3. Verificar servidor (ping)")
+		fmt.Println("4. Salir")
 		fmt.Print("Seleccione una opción: ")
 
 		entrada, _ := reader.ReadString('\n')
@@ -43,6 +43,8 @@ func main() {
 		case "2":
 			forzarEstadoServidor(client, reader)
 		case "3":
+			verificarServidor(client, reader)
+		case "4":
 			fmt.Println("Saliendo del Cliente Administrador.")
 			return
 		default:
@@ -63,14 +65,45 @@ func mostrarEstadoSistema(client pb.ComunicacionServiceClient) {
 	}
 
 	fmt.Println("\n--- Estado de los Servidores ---")
+	fmt.Printf("Estrategia de selección: %s\n", res.SelectionStrategy)
 	for _, srv := range res.Servers {
-		fmt.Printf("ID: %s | Estado: %s | Dirección: %s | MatchID: %d\n",
-			srv.Id, srv.Status, srv.Address, srv.CurrentMatchId)
+		lease := "vigente hasta " + srv.LeaseExpiresAt
+		if srv.LeaseExpired {
+			lease = "EXPIRADO desde " + srv.LeaseExpiresAt
+		}
+		fmt.Printf("ID: %s | Estado: %s | Dirección: %s | MatchID: %d | Capacidad: %d | Partidas asignadas: %d | Última señal: %s (HLC %s) | Lease: %s\n",
+			srv.Id, srv.Status, srv.Address, srv.CurrentMatchId, srv.Capacity, srv.MatchesAssigned, srv.LastUpdate, srv.LastUpdateHlc, lease)
+	}
+
+	fmt.Println("\n--- Conexiones del Matchmaker ---")
+	for _, c := range res.Connections {
+		fmt.Printf("%s (%s) | Estado: %s desde %s | Reconexiones: %d\n",
+			c.Peer, c.Address, c.State, c.Since, c.Reconnections)
+	}
+	fmt.Println("\n--- Conexiones de este cliente con las réplicas ---")
+	for _, c := range enrutador.estados() {
+		fmt.Println(c)
+	}
+
+	fmt.Println("\n--- Partidas Activas ---")
+	for _, m := range res.Matches {
+		fmt.Printf("MatchID: %d | Modo: %s | Servidor: %s (%s) | Estado: %s | Inicio: %s\n",
+			m.MatchId, m.GameMode, m.ServerId, m.ServerAddress, m.Status, m.StartTime)
+		for _, team := range m.Teams {
+			fmt.Printf("  Equipo %d: %v\n", team.TeamId, team.PlayersIds)
+		}
 	}
 
-	fmt.Println("\n--- Cola de Jugadores ---")
-	for _, p := range res.PlayerQueue {
-		fmt.Printf("Jugador ID: %d | Tiempo en cola: %s\n", p.PlayerId, p.TimeInQueue)
+	fmt.Println("\n--- Colas de Jugadores ---")
+	for _, q := range res.GameModeQueues {
+		fmt.Printf("[%s] %d jugador(es)\n", q.GameMode, len(q.Players))
+		for _, p := range q.Players {
+			grupo := "-"
+			if p.PartyId != 0 {
+				grupo = fmt.Sprint(p.PartyId)
+			}
+			fmt.Printf("  Jugador ID: %d | Grupo: %s | Rating: %d | Tiempo en cola: %s\n", p.PlayerId, grupo, p.Rating, p.TimeInQueue)
+		}
 	}
 
 	fmt.Println("\nVectorClock del sistema:", res.VectorClock.Clocks)
@@ -81,12 +114,13 @@ func forzarEstadoServidor(client pb.ComunicacionServiceClient, reader *bufio.Rea
 	id, _ := reader.ReadString('\n')
 	id = strings.TrimSpace(id)
 
-	fmt.Print("Ingrese nuevo estado (DISPONIBLE / CAIDO): ")
+	fmt.Print("Ingrese nuevo estado (DISPONIBLE / CAIDO / DRAINING): ")
 	estado, _ := reader.ReadString('\n')
 	estado = strings.ToUpper(strings.TrimSpace(estado))
 
-	if estado != "DISPONIBLE" && estado != "CAIDO" {
-		fmt.Println("Estado inválido. Debe ser DISPONIBLE o CAIDO.")
+	// DRAINING retira el servidor: termina su partida actual y se da de baja
+	if estado != "DISPONIBLE" && estado != "CAIDO" && estado != "DRAINING" {
+		fmt.Println("Estado inválido. Debe ser DISPONIBLE, CAIDO o DRAINING.")
 		return
 	}
 
@@ -106,3 +140,20 @@ func forzarEstadoServidor(client pb.ComunicacionServiceClient, reader *bufio.Rea
 
 	fmt.Printf("Resultado: %s\nMensaje: %s\n", res.StatusCode, res.Message)
 }
+
+func verificarServidor(client pb.ComunicacionServiceClient, reader *bufio.Reader) {
+	fmt.Print("Ingrese el ID del servidor (ej: GameServer1): ")
+	id, _ := reader.ReadString('\n')
+	id = strings.TrimSpace(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := client.PingServer(ctx, &pb.ServerId{ServerId: id})
+	if err != nil {
+		log.Printf("Error al verificar el servidor: %v", err)
+		return
+	}
+
+	fmt.Printf("Estado: %s\nMensaje: %s\n", res.Status, res.Message)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+
+	pb "cliente/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata con la clave de idempotencia de una llamada. Todos los reintentos
+// de una misma operación llevan la misma clave
+const claveIdempotencia = "idempotency-key"
+
+// Llamadas al Matchmaker que cambian su estado. Llevan una clave de
+// idempotencia para que un reintento no se aplique dos veces
+var metodosIdempotentes = map[string]bool{
+	pb.ComunicacionService_QueuePlayer_FullMethodName:            true,
+	pb.ComunicacionService_LeaveQueue_FullMethodName:             true,
+	pb.ComunicacionService_RespondReadyCheck_FullMethodName:      true,
+	pb.ComunicacionService_CreateParty_FullMethodName:            true,
+	pb.ComunicacionService_JoinParty_FullMethodName:              true,
+	pb.ComunicacionService_LeaveParty_FullMethodName:             true,
+	pb.ComunicacionService_UpdateServerStatus_FullMethodName:     true,
+	pb.ComunicacionService_AdminUpdateServerState_FullMethodName: true,
+	pb.ComunicacionService_RenewLease_FullMethodName:             true,
+	pb.ComunicacionService_ReportMatchResult_FullMethodName:      true,
+	pb.ComunicacionService_DeregisterServer_FullMethodName:       true,
+}
+
+var (
+	prefijoClaves = fmt.Sprintf("%x", rand.Uint64()) // distingue las claves de este proceso
+	ultimaClave   atomic.Uint64
+)
+
+// Agrega al contexto una clave de idempotencia nueva. Los reintentos de la
+// misma operación deben usar el contexto devuelto.
+func conIdempotencia(ctx context.Context) context.Context {
+	clave := fmt.Sprintf("%s-%d", prefijoClaves, ultimaClave.Add(1))
+	return metadata.AppendToOutgoingContext(ctx, claveIdempotencia, clave)
+}
+
+// Interceptor que agrega una clave a las llamadas que cambian el estado del
+// Matchmaker, si no traen una. Va antes del enrutador de líder, para que los
+// reintentos en otras réplicas usen la misma clave.
+func agregarClaveIdempotencia(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if metodosIdempotentes[method] {
+		if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(claveIdempotencia)) == 0 {
+			ctx = conIdempotencia(ctx)
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// Conexiones del Matchmaker con los servidores de juego y con las demás
+// réplicas. Se crea una por par y se reutiliza en todas las llamadas; gRPC la
+// restablece sola si se pierde.
+type conexiones struct {
+	mu    sync.Mutex
+	pares map[string]*conexion // ID del par -> conexión
+}
+
+type conexion struct {
+	peer string
+	addr string
+	conn *grpc.ClientConn
+
+	// Protegidos por conexiones.mu
+	estado       connectivity.State
+	desde        time.Time
+	conectada    bool // llegó a READY al menos una vez
+	reconexiones int32
+}
+
+func nuevasConexiones() *conexiones {
+	return &conexiones{pares: make(map[string]*conexion)}
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Cliente para el par, sobre la conexión ya abierta con él o sobre una nueva.
+// Si el par cambió de dirección se descarta la conexión anterior. Una conexión
+// que está esperando para reintentar vuelve a intentar de inmediato, para no
+// hacer esperar a la llamada todo el backoff de gRPC.
+func (c *conexiones) cliente(peer, addr string) (pb.ComunicacionServiceClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cx, ok := c.pares[peer]
+	if ok && cx.addr != addr {
+		log.Printf("[Matchmaker] %s cambió de dirección (%s -> %s). Se reemplaza la conexión", peer, cx.addr, addr)
+		cx.conn.Close()
+		ok = false
+	}
+	if !ok {
+		conn, err := grpc.NewClient(addr, grpc.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+		cx = &conexion{peer: peer, addr: addr, conn: conn, estado: conn.GetState(), desde: time.Now()}
+		c.pares[peer] = cx
+		go c.vigilar(cx)
+	}
+
+	switch cx.conn.GetState() {
+	case connectivity.Idle:
+		cx.conn.Connect()
+	case connectivity.TransientFailure:
+		cx.conn.ResetConnectBackoff()
+	}
+	return pb.NewComunicacionServiceClient(cx.conn), nil
+}
+
+// Cierra la conexión con un par que dejó el sistema.
+func (c *conexiones) cerrar(peer string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cx, ok := c.pares[peer]; ok {
+		cx.conn.Close()
+		delete(c.pares, peer)
+	}
+}
+
+// Sigue los cambios de estado de una conexión hasta que se cierra. Solo se
+// informa cuando una conexión que funcionaba se pierde o se recupera.
+func (c *conexiones) vigilar(cx *conexion) {
+	estado := cx.conn.GetState()
+	for {
+		c.mu.Lock()
+		anterior := cx.estado
+		cx.estado = estado
+		cx.desde = time.Now()
+		switch {
+		case estado == connectivity.Ready && cx.conectada:
+			cx.reconexiones++
+			log.Printf("[Matchmaker] Conexión con %s (%s) restablecida", cx.peer, cx.addr)
+		case estado == connectivity.Ready:
+			cx.conectada = true
+		case anterior == connectivity.Ready && estado != connectivity.Shutdown:
+			log.Printf("[Matchmaker] Conexión con %s (%s) perdida. Se reconectará al volver a usarla", cx.peer, cx.addr)
+		}
+		c.mu.Unlock()
+
+		if estado == connectivity.Shutdown || !cx.conn.WaitForStateChange(context.Background(), estado) {
+			return
+		}
+		estado = cx.conn.GetState()
+	}
+}
+
+// Estado de todas las conexiones, para el administrador.
+func (c *conexiones) estados() []*pb.PeerConnection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lista := make([]*pb.PeerConnection, 0, len(c.pares))
+	for _, cx := range c.pares {
+		lista = append(lista, &pb.PeerConnection{
+			Peer:          cx.peer,
+			Address:       cx.addr,
+			State:         cx.estado.String(),
+			Since:         cx.desde.Format(time.RFC3339),
+			Reconnections: cx.reconexiones,
+		})
+	}
+	sort.Slice(lista, func(i, j int) bool { return lista[i].Peer < lista[j].Peer })
+	return lista
+}
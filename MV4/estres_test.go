@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Prueba de concurrencia del Matchmaker. Muchos jugadores simulados entran y
+// salen de la cola, forman grupos, aceptan partidas y consultan su estado a
+// la vez, mientras servidores de juego simulados reciben partidas, informan
+// resultados y renuevan su lease. Conviene correrla con el detector de
+// carreras, y más larga que en las pruebas normales:
+//
+//	go test -race -run Estres -estres.duracion 30s -v
+var (
+	duracionEstres   = flag.Duration("estres.duracion", 4*time.Second, "duración de la prueba de concurrencia")
+	jugadoresEstres  = flag.Int("estres.jugadores", 100, "jugadores simulados en la prueba de concurrencia")
+	servidoresEstres = flag.Int("estres.servidores", 8, "servidores de juego simulados en la prueba de concurrencia")
+)
+
+var modosEstres = []string{"Casual", "Ranked", "Duos"}
+
+// Llamadas hechas y fallidas por RPC
+type contadores struct {
+	mu       sync.Mutex
+	llamadas map[string]int
+	errores  map[string][]error
+}
+
+func (c *contadores) contar(rpc string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.llamadas[rpc]++
+	// Las llamadas cortadas al terminar la prueba no son errores
+	if code := status.Code(err); err != nil && code != codes.Canceled && code != codes.DeadlineExceeded {
+		c.errores[rpc] = append(c.errores[rpc], err)
+	}
+}
+
+// ===== SERVIDOR DE JUEGO SIMULADO =====
+
+type servidorSimulado struct {
+	pb.UnimplementedComunicacionServiceServer
+	id       string
+	addr     string
+	client   pb.ComunicacionServiceClient
+	stats    *contadores
+	partidas sync.WaitGroup
+	fin      chan struct{} // si no es nil, las partidas duran hasta que se cierra
+}
+
+// Confirma la partida y la "juega" en segundo plano durante unos cientos de
+// milisegundos.
+func (g *servidorSimulado) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	g.partidas.Add(1)
+	go g.jugar(req)
+	return &pb.AssignMatchResponse{Message: "Partida recibida", MatchId: req.MatchId, MatchServerAddress: g.addr}, nil
+}
+
+func (g *servidorSimulado) PingServer(ctx context.Context, req *pb.ServerId) (*pb.PingResponse, error) {
+	return &pb.PingResponse{Status: "DISPONIBLE", Message: g.id + " activo"}, nil
+}
+
+func (g *servidorSimulado) jugar(req *pb.AssignMatchRequest) {
+	defer g.partidas.Done()
+	inicio := time.Now()
+	g.actualizar("OCUPADO")
+	if g.fin != nil {
+		<-g.fin
+	} else {
+		time.Sleep(time.Duration(200+rand.Intn(600)) * time.Millisecond)
+	}
+
+	var ganador int32
+	if len(req.Teams) > 0 {
+		ganador = req.Teams[rand.Intn(len(req.Teams))].TeamId
+	}
+	_, err := g.client.ReportMatchResult(context.Background(), &pb.MatchResultRequest{
+		MatchId:       req.MatchId,
+		ServerId:      g.id,
+		WinningTeamId: ganador,
+		DurationMs:    time.Since(inicio).Milliseconds(),
+		VectorClock:   &pb.VectorClock{},
+	})
+	g.stats.contar("ReportMatchResult", err)
+	g.actualizar("DISPONIBLE")
+}
+
+func (g *servidorSimulado) actualizar(estado string) {
+	_, err := g.client.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+		ServerId:    g.id,
+		NewStatus:   estado,
+		Address:     g.addr,
+		Capacity:    1,
+		VectorClock: &pb.VectorClock{},
+	})
+	g.stats.contar("UpdateServerStatus", err)
+}
+
+func (g *servidorSimulado) renovar(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		_, err := g.client.RenewLease(ctx, &pb.LeaseRequest{ServerId: g.id, RenewIntervalMs: 1000, VectorClock: &pb.VectorClock{}})
+		g.stats.contar("RenewLease", err)
+	}
+}
+
+// Levanta un servidor de juego simulado en un puerto libre y lo registra.
+func iniciarServidorSimulado(t *testing.T, ctx context.Context, i int, client pb.ComunicacionServiceClient, stats *contadores) *servidorSimulado {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error al escuchar: %v", err)
+	}
+	g := &servidorSimulado{id: fmt.Sprintf("Estres%d", i), addr: lis.Addr().String(), client: client, stats: stats}
+	s := grpc.NewServer()
+	pb.RegisterComunicacionServiceServer(s, g)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	g.actualizar("DISPONIBLE")
+	go g.renovar(ctx)
+	return g
+}
+
+// ===== JUGADOR SIMULADO =====
+
+// Elige una operación al azar según el estado del jugador. Los jugadores con
+// una partida encontrada o en curso a veces intentan volver a la cola o
+// cambiar de grupo, cosa que el Matchmaker debe rechazar.
+func jugarEstres(ctx context.Context, client pb.ComunicacionServiceClient, id int32, r *rand.Rand, stats *contadores) {
+	vc := &pb.VectorClock{}
+	for ctx.Err() == nil {
+		st, err := client.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id, VectorClock: vc})
+		stats.contar("GetPlayerStatus", err)
+		if err != nil {
+			continue
+		}
+
+		switch st.Status {
+		case "MATCH FOUND", "IN MATCH":
+			switch x := r.Float32(); {
+			case x < 0.2:
+				_, err = client.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id, GameModePreference: modosEstres[r.Intn(len(modosEstres))], VectorClock: vc})
+				stats.contar("QueuePlayer", err)
+			case x < 0.3:
+				_, err = client.JoinParty(ctx, &pb.PartyRequest{PlayerId: id, PartyId: int32(1 + r.Intn(20)), VectorClock: vc})
+				stats.contar("JoinParty", err)
+			case st.Status == "MATCH FOUND":
+				_, err = client.RespondReadyCheck(ctx, &pb.ReadyCheckRequest{PlayerId: id, MatchId: st.MatchId, Accept: r.Float32() < 0.9, VectorClock: vc})
+				stats.contar("RespondReadyCheck", err)
+			}
+		case "IN QUEUE":
+			if r.Float32() < 0.3 {
+				_, err = client.EstimateWaitTime(ctx, &pb.WaitTimeRequest{PlayerId: id, VectorClock: vc})
+				stats.contar("EstimateWaitTime", err)
+			} else if r.Float32() < 0.1 {
+				_, err = client.LeaveQueue(ctx, &pb.LeaveQueueRequest{PlayerId: id, VectorClock: vc})
+				stats.contar("LeaveQueue", err)
+			}
+		default:
+			switch x := r.Float32(); {
+			case x < 0.1 && st.PartyId == 0:
+				_, err = client.CreateParty(ctx, &pb.PartyRequest{PlayerId: id, VectorClock: vc})
+				stats.contar("CreateParty", err)
+			case x < 0.2 && st.PartyId == 0:
+				_, err = client.JoinParty(ctx, &pb.PartyRequest{PlayerId: id, PartyId: int32(1 + r.Intn(20)), VectorClock: vc})
+				stats.contar("JoinParty", err)
+			case x < 0.25 && st.PartyId != 0:
+				_, err = client.LeaveParty(ctx, &pb.PartyRequest{PlayerId: id, VectorClock: vc})
+				stats.contar("LeaveParty", err)
+			default:
+				_, err = client.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id, GameModePreference: modosEstres[r.Intn(len(modosEstres))], VectorClock: vc})
+				stats.contar("QueuePlayer", err)
+			}
+		}
+		time.Sleep(time.Duration(r.Intn(50)) * time.Millisecond)
+	}
+}
+
+// ===== VERIFICACIÓN =====
+
+// Problemas del estado del Matchmaker: un jugador en dos partidas activas, en
+// dos colas, o en una cola y en una partida activa a la vez.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) inconsistencias() []string {
+	var problemas []string
+	enPartida := make(map[int32]int32)
+	for _, m := range s.matches {
+		if !m.activa() {
+			continue
+		}
+		for _, id := range m.jugadores() {
+			if otra, ok := enPartida[id]; ok {
+				problemas = append(problemas, fmt.Sprintf("jugador %d en las partidas %d y %d", id, otra, m.ID))
+			}
+			enPartida[id] = m.ID
+		}
+	}
+	enCola := make(map[int32]string)
+	for mode, queue := range s.playersQueue {
+		for _, entry := range queue {
+			for _, id := range entry.Players {
+				if otro, ok := enCola[id]; ok {
+					problemas = append(problemas, fmt.Sprintf("jugador %d en las colas %s y %s", id, otro, mode))
+				}
+				enCola[id] = mode
+				if m, ok := enPartida[id]; ok {
+					problemas = append(problemas, fmt.Sprintf("jugador %d en la cola %s y en la partida %d", id, mode, m))
+				}
+			}
+		}
+	}
+	return problemas
+}
+
+// ===== PRUEBA =====
+
+func TestEstresConcurrencia(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	for id := range c.peers {
+		c.arrancar(id)
+	}
+	liderID, _ := c.esperarLider()
+	lider := c.replicas[liderID].srv
+	client := c.cliente(liderID)
+	stats := &contadores{llamadas: make(map[string]int), errores: make(map[string][]error)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duracionEstres)
+	defer cancel()
+
+	var servidores []*servidorSimulado
+	for i := 1; i <= *servidoresEstres; i++ {
+		servidores = append(servidores, iniciarServidorSimulado(t, ctx, i, client, stats))
+	}
+
+	// El estado se revisa durante toda la prueba, no solo al final: un
+	// jugador que vuelve a la cola en medio de una partida puede quedar
+	// consistente de nuevo cuando la partida termina
+	problemas := make(map[string]bool)
+	revisar := func() {
+		for _, p := range lider.inconsistencias() {
+			problemas[p] = true
+		}
+	}
+	revision := make(chan struct{})
+	go func() {
+		defer close(revision)
+		for ctx.Err() == nil {
+			lider.ejecutar(revisar)
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= *jugadoresEstres; i++ {
+		wg.Add(1)
+		go func(id int32) {
+			defer wg.Done()
+			jugarEstres(ctx, client, id, rand.New(rand.NewSource(int64(id))), stats)
+		}(int32(1000 + i))
+	}
+	wg.Wait()
+	<-revision
+	for _, g := range servidores {
+		g.partidas.Wait()
+	}
+
+	lider.ejecutar(revisar)
+	for p := range problemas {
+		t.Errorf("Estado inconsistente: %s", p)
+	}
+
+	stats.mu.Lock()
+	rpcs := make([]string, 0, len(stats.llamadas))
+	for rpc := range stats.llamadas {
+		rpcs = append(rpcs, rpc)
+	}
+	sort.Strings(rpcs)
+	var tabla strings.Builder
+	for _, rpc := range rpcs {
+		fmt.Fprintf(&tabla, "\n  %-22s %7d llamadas (%6.1f/s) %5d errores", rpc, stats.llamadas[rpc],
+			float64(stats.llamadas[rpc])/duracionEstres.Seconds(), len(stats.errores[rpc]))
+		for _, err := range stats.errores[rpc] {
+			t.Errorf("%s: %v", rpc, err)
+		}
+	}
+	stats.mu.Unlock()
+	t.Logf("%d jugadores y %d servidores durante %v:%s", *jugadoresEstres, *servidoresEstres, *duracionEstres, tabla.String())
+
+	// Las réplicas terminan con el mismo estado que el líder
+	for id := range c.peers {
+		if id != liderID {
+			c.esperarAlDia(id, liderID)
+		}
+	}
+}
+
+// Un jugador con una partida encontrada o en curso no puede volver a la cola
+// ni crear un grupo: la llamada se rechaza y la partida sigue.
+func TestNoReencolaJugadorConPartida(t *testing.T) {
+	c := nuevoClusterPrueba(t, 1)
+	c.arrancar("1")
+	c.esperarLider()
+	cli := c.cliente("1")
+	stats := &contadores{llamadas: make(map[string]int), errores: make(map[string][]error)}
+
+	ctx := contexto(t)
+	g := iniciarServidorSimulado(t, ctx, 1, cli, stats)
+	g.fin = make(chan struct{})
+	defer g.partidas.Wait()
+	defer close(g.fin)
+
+	for _, jugador := range []int32{1, 2} {
+		if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: jugador}); err != nil {
+			t.Fatalf("QueuePlayer(%d): %v", jugador, err)
+		}
+	}
+
+	// Espera a que el jugador llegue al estado indicado
+	esperarEstado := func(jugador int32, estado string) *pb.PlayerStatusResponse {
+		t.Helper()
+		for limite := time.Now().Add(esperaPrueba); time.Now().Before(limite); time.Sleep(20 * time.Millisecond) {
+			res, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: jugador})
+			if err != nil {
+				t.Fatalf("GetPlayerStatus(%d): %v", jugador, err)
+			}
+			if res.Status == estado {
+				return res
+			}
+		}
+		t.Fatalf("El jugador %d no llegó al estado %s", jugador, estado)
+		return nil
+	}
+	// Intenta volver a la cola y crear un grupo; ambas llamadas deben
+	// rechazarse sin cambiar el estado del jugador
+	reencolar := func(jugador int32, estado string) {
+		t.Helper()
+		res, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: jugador, GameModePreference: "Ranked"})
+		if err != nil {
+			t.Fatalf("QueuePlayer(%d): %v", jugador, err)
+		}
+		if !strings.Contains(res.Message, "ya tiene una partida activa") {
+			t.Errorf("Con el jugador %d en %s, QueuePlayer respondió %q", jugador, estado, res.Message)
+		}
+		grupo, err := cli.CreateParty(ctx, &pb.PartyRequest{PlayerId: jugador})
+		if err != nil {
+			t.Fatalf("CreateParty(%d): %v", jugador, err)
+		}
+		if grupo.StatusCode != "FAILURE" {
+			t.Errorf("Con el jugador %d en %s, CreateParty respondió %s: %s", jugador, estado, grupo.StatusCode, grupo.Message)
+		}
+		esperarEstado(jugador, estado)
+	}
+
+	matchID := esperarEstado(1, "MATCH FOUND").MatchId
+	reencolar(1, "MATCH FOUND")
+
+	for _, jugador := range []int32{1, 2} {
+		if _, err := cli.RespondReadyCheck(ctx, &pb.ReadyCheckRequest{PlayerId: jugador, MatchId: matchID, Accept: true}); err != nil {
+			t.Fatalf("RespondReadyCheck(%d): %v", jugador, err)
+		}
+	}
+	esperarEstado(2, "IN MATCH")
+	reencolar(2, "IN MATCH")
+
+	srv := c.replicas["1"].srv
+	srv.ejecutar(func() {
+		for _, p := range srv.inconsistencias() {
+			t.Errorf("Estado inconsistente: %s", p)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// Metadata con la clave de idempotencia de una llamada. Los clientes
+	// envían la misma clave en todos los reintentos de una operación
+	claveIdempotencia = "idempotency-key"
+
+	// Tiempo durante el que se recuerda la respuesta a una clave
+	retencionIdempotencia = 10 * time.Minute
+)
+
+// Respuesta ya enviada a una clave de idempotencia. Forma parte del estado
+// replicado, para que un reintento que llega a un líder nuevo también reciba
+// la respuesta original.
+type respuestaGuardada struct {
+	Metodo    string
+	Tipo      string // URL del tipo del mensaje de respuesta
+	Respuesta []byte
+	Vence     time.Time
+}
+
+// ===================== INTERCEPTOR =========================
+
+// Las llamadas con clave de idempotencia se ejecutan una sola vez: los
+// reintentos reciben la respuesta original, y si llegan mientras la original
+// todavía se procesa, la esperan.
+func (s *server) interceptorIdempotencia(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	clave := claveDe(ctx)
+	if clave == "" {
+		return handler(ctx, req)
+	}
+
+	for {
+		var guardada *respuestaGuardada
+		var listo chan struct{}
+		propia := false
+		s.ejecutarEn(ctx, func() {
+			if r, ok := s.respuestas[clave]; ok {
+				guardada = r
+				return
+			}
+			if l, ok := s.enCurso[clave]; ok {
+				listo = l
+				return
+			}
+			listo = make(chan struct{})
+			s.enCurso[clave] = listo
+			propia = true
+		})
+
+		switch {
+		case guardada != nil:
+			// La llamada original pudo no haber terminado de esperar la
+			// confirmación de su respuesta: el reintento espera lo mismo
+			esperarEntrada(ctx, s.raft.ultimaEntrada())
+			res, err := guardada.mensaje(info.FullMethod)
+			if err == nil {
+				log.Printf("[Matchmaker] Reintento de %s (clave %s): se devuelve la respuesta original", info.FullMethod, clave)
+			}
+			return res, err
+		case propia:
+			res, err := handler(ctx, req)
+			s.ejecutarEn(ctx, func() {
+				delete(s.enCurso, clave)
+				close(listo)
+				if err == nil {
+					s.guardarRespuesta(clave, info.FullMethod, res)
+				}
+			})
+			return res, err
+		}
+
+		select {
+		case <-listo:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Clave de idempotencia de la llamada, o "" si no trae.
+func claveDe(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(claveIdempotencia); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// Guarda la respuesta a una clave y olvida las que ya vencieron.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) guardarRespuesta(clave, metodo string, res interface{}) {
+	ahora := time.Now()
+	for k, r := range s.respuestas {
+		if ahora.After(r.Vence) {
+			s.marcar("respuesta/" + k)
+			delete(s.respuestas, k)
+		}
+	}
+
+	msg, ok := res.(proto.Message)
+	if !ok {
+		return
+	}
+	a, err := anypb.New(msg)
+	if err != nil {
+		log.Printf("[Matchmaker] No se pudo guardar la respuesta de %s (clave %s): %v", metodo, clave, err)
+		return
+	}
+	s.marcar("respuesta/" + clave)
+	s.respuestas[clave] = &respuestaGuardada{
+		Metodo:    metodo,
+		Tipo:      a.TypeUrl,
+		Respuesta: a.Value,
+		Vence:     ahora.Add(retencionIdempotencia),
+	}
+}
+
+// Reconstruye la respuesta guardada. Una clave usada antes con otro método
+// es un error del cliente.
+func (r *respuestaGuardada) mensaje(metodo string) (interface{}, error) {
+	if r.Metodo != metodo {
+		return nil, status.Errorf(codes.InvalidArgument, "la clave de idempotencia ya se usó con %s", r.Metodo)
+	}
+	msg, err := (&anypb.Any{TypeUrl: r.Tipo, Value: r.Respuesta}).UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "respuesta guardada inválida: %v", err)
+	}
+	return msg, nil
+}
+
+// Contexto para enviar AssignMatch con una clave propia de la partida y el
+// servidor, de modo que los reintentos (también los de otro líder) no inicien
+// la partida dos veces.
+func contextoAsignacion(ctx context.Context, req *pb.AssignMatchRequest, serverID string) context.Context {
+	clave := fmt.Sprintf("Matchmaker-partida-%d-%s", req.MatchId, serverID)
+	return metadata.AppendToOutgoingContext(ctx, claveIdempotencia, clave)
+}
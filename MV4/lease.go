@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Parámetros de los leases de registro de los servidores de juego
+const (
+	// Un lease dura varios intervalos de renovación, para que una renovación
+	// perdida no deje al servidor fuera
+	leaseFactor = 3
+	// Intervalo supuesto para los servidores que todavía no renovaron su lease
+	leaseIntervaloDefault = 3 * time.Second
+)
+
+// Lease de registro de un servidor de juego. Mientras esté vigente, el
+// servidor puede recibir partidas.
+type lease struct {
+	Intervalo time.Duration // intervalo de renovación informado por el servidor
+	Vence     time.Time
+	Expirado  bool // ya se registró en el log que venció
+}
+
+// ===================== RPCS =========================
+
+func (s *server) RenewLease(ctx context.Context, req *pb.LeaseRequest) (*pb.LeaseResponse, error) {
+	s.registrarEvento(reloj.Recepcion, "RenewLease de "+req.ServerId, req.VectorClock)
+
+	// Si el Matchmaker no conoce al servidor (por ejemplo, porque se
+	// reinició), el servidor debe registrarse de nuevo con UpdateServerStatus
+	gs, ok := s.gameServers[req.ServerId]
+	if !ok {
+		return &pb.LeaseResponse{
+			StatusCode:  "FAILURE",
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	intervalo := time.Duration(req.RenewIntervalMs) * time.Millisecond
+	l := s.renovarLease(gs.ID, intervalo)
+	gs.LastUpdate = time.Now()
+	gs.HLC = s.hlc.Actual()
+	s.marcarServidor(gs.ID)
+
+	// Un servidor puesto en DRAINING por el administrador se entera aquí de
+	// que debe terminar su partida y retirarse
+	return &pb.LeaseResponse{
+		StatusCode:  "SUCCESS",
+		ExpiresAt:   l.Vence.Format(time.RFC3339),
+		VectorClock: s.relojProto(),
+		Drain:       gs.Status == "DRAINING",
+	}, nil
+}
+
+// Un servidor que se detiene se da de baja: deja de figurar en el sistema y
+// no recibe más partidas.
+func (s *server) DeregisterServer(ctx context.Context, req *pb.DeregisterRequest) (*pb.DeregisterResponse, error) {
+	s.registrarEvento(reloj.Recepcion, "DeregisterServer de "+req.ServerId, req.VectorClock)
+
+	if _, ok := s.gameServers[req.ServerId]; !ok {
+		return &pb.DeregisterResponse{
+			StatusCode:  "SUCCESS",
+			Message:     fmt.Sprintf("%s no estaba registrado", req.ServerId),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+	if matchID, ok := s.serverMatch[req.ServerId]; ok {
+		return &pb.DeregisterResponse{
+			StatusCode:  "FAILURE",
+			Message:     fmt.Sprintf("%s todavía tiene la partida %d", req.ServerId, matchID),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	delete(s.gameServers, req.ServerId)
+	delete(s.leases, req.ServerId)
+	delete(s.asignadas, req.ServerId)
+	s.marcarServidor(req.ServerId)
+	s.conexiones.cerrar(req.ServerId)
+	s.darDeBajaMiembro(req.ServerId)
+	log.Printf("[Matchmaker] %s se dio de baja", req.ServerId)
+
+	return &pb.DeregisterResponse{
+		StatusCode:  "SUCCESS",
+		Message:     fmt.Sprintf("%s dado de baja", req.ServerId),
+		VectorClock: s.relojProto(),
+	}, nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Extiende el lease del servidor por leaseFactor intervalos de renovación.
+// Con intervalo 0 se conserva el último informado por el servidor.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) renovarLease(id string, intervalo time.Duration) *lease {
+	ahora := time.Now()
+	s.marcarServidor(id)
+	l, ok := s.leases[id]
+	if !ok {
+		l = &lease{Intervalo: leaseIntervaloDefault}
+		s.leases[id] = l
+	} else if !ahora.Before(l.Vence) {
+		log.Printf("[Matchmaker] %s renovó su lease después de vencer", id)
+		s.despertarMatchmaking()
+	}
+	if intervalo > 0 {
+		l.Intervalo = intervalo
+	}
+	l.Vence = ahora.Add(leaseFactor * l.Intervalo)
+	l.Expirado = false
+	return l
+}
+
+// Indica si el lease del servidor está vigente.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) leaseVigente(id string, ahora time.Time) bool {
+	l, ok := s.leases[id]
+	return ok && ahora.Before(l.Vence)
+}
+
+// Registra en el log los leases que vencieron desde la última revisión.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) revisarLeases(ahora time.Time) {
+	for id, l := range s.leases {
+		if !l.Expirado && !ahora.Before(l.Vence) {
+			l.Expirado = true
+			s.marcarServidor(id)
+			s.registrarEvento(reloj.Local, "Venció el lease de "+id, nil)
+			log.Printf("[Matchmaker] El lease de %s venció. No recibirá partidas hasta que lo renueve", id)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata con la dirección del líder que devuelven las réplicas que no lo
+// son, para que el cliente reintente allí.
+const claveLider = "lider"
+
+// ===================== INTERCEPTOR =========================
+
+// Solo el líder atiende a jugadores, servidores de juego y administrador. Las
+// demás réplicas rechazan la llamada indicando quién es el líder, y el líder
+// responde recién cuando los cambios de la llamada están confirmados por la
+// mayoría del cluster. Las llamadas que no cambiaron nada (las consultas)
+// responden sin esperar.
+func (s *server) interceptorLider(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	switch info.FullMethod {
+	case pb.ComunicacionService_RequestVote_FullMethodName,
+		pb.ComunicacionService_AppendEntries_FullMethodName,
+		pb.ComunicacionService_InstallSnapshot_FullMethodName:
+		return handler(ctx, req)
+	}
+
+	liderID, liderAddr, hayLider := s.raft.lider()
+	switch {
+	case !hayLider:
+		return nil, status.Error(codes.Unavailable, "el cluster de matchmakers está eligiendo líder")
+	case liderID != s.raft.id:
+		grpc.SetTrailer(ctx, metadata.Pairs(claveLider, liderAddr))
+		return nil, status.Errorf(codes.FailedPrecondition, "la réplica %s no es el líder; el líder es %s (%s)", s.raft.id, liderID, liderAddr)
+	case !s.sirviendo.Load():
+		return nil, status.Error(codes.Unavailable, "el líder todavía está recuperando el estado")
+	case s.cerrando.Load() && info.FullMethod == pb.ComunicacionService_QueuePlayer_FullMethodName:
+		return nil, status.Error(codes.Unavailable, "el Matchmaker se está apagando")
+	}
+
+	propuesta := new(atomic.Int64)
+	res, err := handler(context.WithValue(ctx, clavePropuesta{}, propuesta), req)
+	if err != nil {
+		return nil, err
+	}
+	if indice := propuesta.Load(); indice > 0 {
+		if err := s.raft.esperarCommit(indice); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "operación no confirmada: %v", err)
+		}
+	}
+	return res, nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// La réplica fue elegida líder y ya aplicó todo el log confirmado: reconstruye
+// el estado desde esa imagen y empieza a atender.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) asumirLiderazgo(term int64, img imagenEstado) {
+	if !s.raft.esLiderEn(term) {
+		return
+	}
+
+	s.reiniciarEstado()
+	if err := s.restaurar(img); err != nil {
+		log.Fatalf("[Matchmaker] Error al restaurar el estado replicado: %v", err)
+	}
+	s.base = img
+	s.sirviendo.Store(true)
+	s.reanudar()
+	log.Printf("[Matchmaker] Réplica %s atendiendo como líder (término %d): %d partidas, %d servidores, reloj %v",
+		s.raft.id, term, len(s.matches), len(s.gameServers), s.vectorClock)
+}
+
+// La réplica dejó de ser líder: deja de atender y de emparejar. Su estado en
+// memoria se descarta la próxima vez que sea elegida.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) dejarLiderazgo() {
+	if s.raft.esLider() {
+		return
+	}
+	s.sirviendo.Store(false)
+	log.Printf("[Matchmaker] Réplica %s deja de atender", s.raft.id)
+}
+
+// Deja el estado del Matchmaker vacío, como al arrancar por primera vez.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) reiniciarEstado() {
+	s.playersQueue = make(map[string][]*queueEntry)
+	s.playerMode = make(map[int32]string)
+	s.playerRating = make(map[int32]float64)
+	s.playerStatus = make(map[int32]string)
+	s.playerMatch = make(map[int32]int32)
+	s.matches = make(map[int32]*Match)
+	s.serverMatch = make(map[string]int32)
+	s.cancelados = make(map[int32]bool)
+	s.throughput = make(map[string][]muestraThroughput)
+	s.startedAt = time.Now()
+	s.readyChecks = make(map[int32]*readyCheck)
+	s.parties = make(map[int32]*Party)
+	s.playerParty = make(map[int32]int32)
+	s.nextPartyID = 1
+	s.playerVC = make(map[int32]reloj.Reloj)
+	s.gameServers = make(map[string]*GameServerInfo)
+	s.leases = make(map[string]*lease)
+	s.asignadas = make(map[string]int32)
+	s.vectorClock = reloj.Nuevo("Matchmaker")
+	s.miembros = make(map[string]*miembro)
+	s.nextMatchID = 1
+	s.respuestas = make(map[string]*respuestaGuardada)
+	s.sucias = make(map[string]bool)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Registro de una partida creada por el matchmaker. Se conserva después de
+// terminar para poder consultarla.
+type Match struct {
+	ID            int32
+	Mode          string
+	ServerID      string
+	ServerAddress string
+	Teams         [][]int32
+	Status        string // "MATCH FOUND", "EN CURSO", "FINALIZADA" o "CANCELADA"
+	CreatedAt     time.Time
+	StartTime     time.Time // momento en que se asignó al servidor
+	EndTime       time.Time
+	Recibida      bool          // el servidor confirmó que recibió la partida
+	WinningTeam   int32         // informado por el servidor al terminar
+	Duration      time.Duration // duración informada por el servidor
+}
+
+// Jugadores de la partida, en el orden de los equipos.
+func (m *Match) jugadores() []int32 {
+	var players []int32
+	for _, team := range m.Teams {
+		players = append(players, team...)
+	}
+	return players
+}
+
+// Indica si la partida todavía ocupa a sus jugadores y a su servidor.
+func (m *Match) activa() bool {
+	return m.Status == "MATCH FOUND" || m.Status == "EN CURSO"
+}
+
+// Convierte el registro al mensaje que se envía a jugadores y administradores.
+func (m *Match) toProto() *pb.MatchInfo {
+	info := &pb.MatchInfo{
+		MatchId:       m.ID,
+		ServerId:      m.ServerID,
+		ServerAddress: m.ServerAddress,
+		GameMode:      m.Mode,
+		Status:        m.Status,
+	}
+	for t, team := range m.Teams {
+		info.Teams = append(info.Teams, &pb.Team{TeamId: int32(t + 1), PlayersIds: team})
+	}
+	if !m.StartTime.IsZero() {
+		info.StartTime = m.StartTime.Format(time.RFC3339)
+	}
+	return info
+}
+
+// ===================== RPCS =========================
+
+// El servidor de juego informa que terminó una partida. La partida queda
+// FINALIZADA y sus jugadores IDLE.
+func (s *server) ReportMatchResult(ctx context.Context, req *pb.MatchResultRequest) (*pb.MatchResultResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("ReportMatchResult de %s para la partida %d", req.ServerId, req.MatchId), req.VectorClock)
+
+	match, ok := s.matches[req.MatchId]
+	if !ok || match.ServerID != req.ServerId {
+		return &pb.MatchResultResponse{
+			StatusCode:  "FAILURE",
+			Message:     fmt.Sprintf("La partida %d no fue asignada a %s", req.MatchId, req.ServerId),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+	if match.Status != "EN CURSO" {
+		return &pb.MatchResultResponse{
+			StatusCode:  "FAILURE",
+			Message:     fmt.Sprintf("La partida %d no está en curso (%s)", match.ID, match.Status),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	s.cerrarPartida(match, "FINALIZADA")
+	match.WinningTeam = req.WinningTeamId
+	match.Duration = time.Duration(req.DurationMs) * time.Millisecond
+	s.liberarJugadores(match)
+	s.actualizarRating(match.Teams, req.WinningTeamId)
+	log.Printf("[Matchmaker] Partida %d finalizada en %s tras %v. Equipo ganador: %d",
+		match.ID, match.ServerID, match.Duration.Round(time.Millisecond), req.WinningTeamId)
+
+	return &pb.MatchResultResponse{
+		StatusCode:  "SUCCESS",
+		Message:     fmt.Sprintf("Resultado de la partida %d registrado", match.ID),
+		VectorClock: s.relojProto(),
+	}, nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Registra una nueva partida en el servidor indicado.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) registrarPartida(mode string, gs *GameServerInfo, teams [][]int32) *Match {
+	match := &Match{
+		ID:            s.nextMatchID,
+		Mode:          mode,
+		ServerID:      gs.ID,
+		ServerAddress: gs.Address,
+		Teams:         teams,
+		Status:        "MATCH FOUND",
+		CreatedAt:     time.Now(),
+	}
+	s.nextMatchID++
+	s.matches[match.ID] = match
+	s.serverMatch[gs.ID] = match.ID
+	for _, id := range match.jugadores() {
+		s.playerMatch[id] = match.ID
+	}
+	s.marcar("meta")
+	s.marcarPartida(match.ID)
+	s.marcarServidor(gs.ID)
+	s.marcarJugadores(match.jugadores()...)
+	return match
+}
+
+// Cierra la partida con el estado final indicado y libera el servidor.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) cerrarPartida(match *Match, status string) {
+	match.Status = status
+	match.EndTime = time.Now()
+	s.marcarPartida(match.ID)
+	s.marcarServidor(match.ServerID)
+	if s.serverMatch[match.ServerID] == match.ID {
+		delete(s.serverMatch, match.ServerID)
+	}
+}
+
+// Deja IDLE a los jugadores que todavía estaban en la partida, ya cerrada.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) liberarJugadores(match *Match) {
+	s.marcar("meta")
+	s.marcarJugadores(match.jugadores()...)
+	for _, id := range match.jugadores() {
+		delete(s.cancelados, id)
+		if s.playerMatch[id] == match.ID {
+			delete(s.playerMatch, id)
+			s.playerStatus[id] = "IDLE"
+		}
+	}
+}
+
+// Cancela la partida en curso del servidor, que quedó CAIDO antes de
+// informar el resultado. Sus jugadores quedan IDLE. Las partidas que el
+// servidor todavía no confirmó no se tocan: de ellas se encarga el envío en
+// curso (ver fallarAsignacion).
+// Debe llamarse desde el bucle de eventos.
+func (s *server) interrumpirPartida(serverID string) {
+	match, ok := s.matches[s.serverMatch[serverID]]
+	if !ok || match.Status != "EN CURSO" || !match.Recibida {
+		return
+	}
+	log.Printf("[Matchmaker] Partida %d cancelada: %s cayó antes de informar el resultado", match.ID, serverID)
+	s.cerrarPartida(match, "CANCELADA")
+	s.liberarJugadores(match)
+}
+
+// Entradas de cola que formaron la partida, reconstruidas a partir de los
+// grupos de sus jugadores. Se usan para devolverlos a la cola si la partida
+// se cancela después de un cambio de líder, cuando las entradas originales
+// ya no están.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) entradasDePartida(match *Match) []*queueEntry {
+	var entries []*queueEntry
+	grupos := make(map[int32]*queueEntry)
+	for _, id := range match.jugadores() {
+		partyID := s.playerParty[id]
+		if entry, ok := grupos[partyID]; ok && partyID != 0 {
+			entry.Players = append(entry.Players, id)
+			continue
+		}
+		entry := &queueEntry{Players: []int32{id}, PartyID: partyID, EnqueuedAt: match.CreatedAt}
+		grupos[partyID] = entry
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Devuelve la partida activa del jugador, si tiene una.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) partidaDe(playerID int32) (*Match, bool) {
+	match, ok := s.matches[s.playerMatch[playerID]]
+	return match, ok
+}
+
+// Indica si el jugador está ocupado por una partida, ya sea esperando el
+// ready-check o en curso. Mientras tanto no puede volver a la cola.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) enPartida(playerID int32) bool {
+	if _, pendiente := s.readyChecks[s.playerMatch[playerID]]; pendiente {
+		return true
+	}
+	match, ok := s.partidaDe(playerID)
+	return ok && match.activa()
+}
@@ -2,216 +2,659 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"math"
 	"net"
-	"sync"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
 
 	"google.golang.org/grpc"
 )
 
 const (
 	address = "localhost:50051"
+
+	// Modo de juego usado cuando el jugador no indica preferencia
+	defaultGameMode = "Casual"
 )
 
+// Configuración de un modo de juego: cantidad de equipos por partida y de
+// jugadores por equipo. Un free-for-all es un modo con equipos de 1 jugador.
+type modeConfig struct {
+	Name     string
+	Teams    int
+	TeamSize int
+}
+
+// Jugadores necesarios para armar una partida del modo.
+func (m modeConfig) jugadores() int {
+	return m.Teams * m.TeamSize
+}
+
+// Indica si un grupo de n jugadores puede entrar a la cola del modo. El grupo
+// debe caber en un solo equipo; en free-for-all basta con que quepa en la
+// partida, ya que cada miembro juega por su cuenta.
+func (m modeConfig) admiteGrupo(n int) bool {
+	if m.TeamSize == 1 {
+		return n <= m.Teams
+	}
+	return n <= m.TeamSize
+}
+
+// Modos de juego soportados. Cada modo tiene su propia cola y sus jugadores
+// solo se emparejan entre sí.
+var gameModes = []modeConfig{
+	{Name: "Casual", Teams: 2, TeamSize: 1},  // 1v1
+	{Name: "Ranked", Teams: 2, TeamSize: 1},  // 1v1
+	{Name: "Duos", Teams: 2, TeamSize: 2},    // 2v2
+	{Name: "Equipos", Teams: 2, TeamSize: 5}, // 5v5
+	{Name: "FFA", Teams: 8, TeamSize: 1},     // free-for-all de 8 jugadores
+}
+
+// Entrada de una cola de emparejamiento: un jugador solo o un grupo completo,
+// que siempre se empareja junto en la misma partida.
+type queueEntry struct {
+	Players    []int32
+	PartyID    int32
+	EnqueuedAt time.Time
+}
+
+// Estado del Matchmaker. Solo lo toca el bucle de eventos (ver bucle.go): los
+// métodos con forma de RPC son los comandos que el bucle ejecuta por cada
+// llamada.
 type server struct {
-	pb.UnimplementedComunicacionServiceServer
-	mu           sync.Mutex
-	playersQueue []int32
+	playersQueue map[string][]*queueEntry // colas de emparejamiento por modo de juego
+	playerMode   map[int32]string         // modo de juego de cada jugador en cola
+	playerRating map[int32]float64
 	playerStatus map[int32]string
-	playerVC     map[int32]map[string]int32
+	playerMatch  map[int32]int32 // partida activa de cada jugador
+	matches      map[int32]*Match
+	serverMatch  map[string]int32               // partida activa de cada servidor
+	cancelados   map[int32]bool                 // jugadores que cancelaron mientras se asignaba su partida
+	throughput   map[string][]muestraThroughput // partidas recientes por modo, para estimar la espera
+	startedAt    time.Time
+	readyChecks  map[int32]*readyCheck
+	parties      map[int32]*Party
+	playerParty  map[int32]int32 // grupo al que pertenece cada jugador
+	nextPartyID  int32
+	playerVC     map[int32]reloj.Reloj
 	gameServers  map[string]*GameServerInfo
-	vectorClock  map[string]int32
+	leases       map[string]*lease // lease de registro de cada servidor
+	selector     selectorServidor
+	asignadas    map[string]int32 // partidas asignadas a cada servidor
+	vectorClock  reloj.Reloj
+	hlc          reloj.Hibrido       // reloj lógico híbrido, avanza junto con vectorClock
+	miembros     map[string]*miembro // procesos que participan de los relojes vectoriales
+	eventos      *reloj.Registro     // registro causal de los eventos de esta réplica
 	nextMatchID  int32
+	ordenes      chan orden    // órdenes para el bucle de eventos
+	avisos       chan struct{} // pide una pasada de emparejamiento al bucle
+
+	// Replicación del estado. Solo el líder atiende clientes, y recién
+	// después de reconstruir su estado desde el log confirmado
+	raft      *nodoRaft
+	sirviendo atomic.Bool
+	base      imagenEstado    // imagen del estado ya propuesta al cluster
+	sucias    map[string]bool // entidades modificadas en el paso actual del bucle
+	cerrando  atomic.Bool     // la réplica se está apagando: no entran jugadores ni se emparejan
+
+	// Respuestas a las llamadas con clave de idempotencia (replicadas) y
+	// llamadas con clave que todavía se están procesando
+	respuestas map[string]*respuestaGuardada
+	enCurso    map[string]chan struct{}
+
+	// Conexiones reutilizadas con los servidores de juego y las réplicas
+	conexiones *conexiones
 }
 
 type GameServerInfo struct {
 	ID         string
 	Address    string
 	Status     string
+	Capacity   int32 // peso del servidor en la selección ponderada
 	LastUpdate time.Time
+	// Marca HLC de LastUpdate: a diferencia de la hora local, se puede
+	// comparar con las marcas de otras máquinas
+	HLC reloj.Marca
+
+	// Marcado CAIDO por no responder al ping: vuelve a DISPONIBLE cuando
+	// responda de nuevo
+	SinRespuesta bool
+
+	// Reloj de la última actualización del servidor que se aplicó. Las que
+	// llegan después sin ser posteriores a ella se descartan
+	VC reloj.Reloj
 }
 
 // ===================== RPCS =========================
 
 func (s *server) QueuePlayer(ctx context.Context, req *pb.PlayerInfoRequest) (*pb.QueuePlayerResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	playerID := req.PlayerId
 
-	s.mergeVectorClock(req.VectorClock.Clocks)
-	s.vectorClock["Matchmaker"]++
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("QueuePlayer del jugador %d (%s)", playerID, req.GameModePreference), req.VectorClock)
 
-	// Verifica si ya está en cola
-	for _, id := range s.playersQueue {
-		if id == playerID {
+	mode, ok := normalizarModo(req.GameModePreference)
+	if !ok {
+		return &pb.QueuePlayerResponse{
+			Message:     fmt.Sprintf("Modo de juego inválido: %s", req.GameModePreference),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	// Un jugador en un grupo solo entra a la cola a través del líder, que
+	// pone en cola a todo el grupo
+	entry := &queueEntry{Players: []int32{playerID}, EnqueuedAt: time.Now()}
+	if party, enGrupo := s.partyDe(playerID); enGrupo {
+		if party.Leader != playerID {
+			return &pb.QueuePlayerResponse{
+				Message:     fmt.Sprintf("Solo el líder del grupo %d (jugador %d) puede unirse a la cola", party.ID, party.Leader),
+				VectorClock: s.relojProto(),
+			}, nil
+		}
+		entry.Players = append([]int32(nil), party.Members...)
+		entry.PartyID = party.ID
+	}
+
+	if cfg := modoDe(mode); !cfg.admiteGrupo(len(entry.Players)) {
+		return &pb.QueuePlayerResponse{
+			Message:     fmt.Sprintf("El modo %s no admite grupos de %d jugadores", mode, len(entry.Players)),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	// Verifica si ya está en alguna cola o tiene una partida activa
+	for _, id := range entry.Players {
+		if actual, enCola := s.playerMode[id]; enCola {
 			return &pb.QueuePlayerResponse{
-				Message:     "Jugador ya está en cola",
-				VectorClock: &pb.VectorClock{Clocks: s.vectorClock},
+				Message:     fmt.Sprintf("Jugador %d ya está en cola (%s)", id, actual),
+				VectorClock: s.relojProto(),
+			}, nil
+		}
+		if s.enPartida(id) {
+			return &pb.QueuePlayerResponse{
+				Message:     fmt.Sprintf("Jugador %d ya tiene una partida activa (%d)", id, s.playerMatch[id]),
+				VectorClock: s.relojProto(),
 			}, nil
 		}
 	}
 
-	s.playersQueue = append(s.playersQueue, playerID)
-	s.playerStatus[playerID] = "IN QUEUE"
+	s.playersQueue[mode] = append(s.playersQueue[mode], entry)
+	for _, id := range entry.Players {
+		s.playerMode[id] = mode
+		s.playerStatus[id] = "IN QUEUE"
+	}
+	s.marcar("cola/" + mode)
+	s.marcarJugadores(entry.Players...)
+
+	if entry.PartyID != 0 {
+		log.Printf("[Matchmaker] Grupo %d %v agregado a la cola %s", entry.PartyID, entry.Players, mode)
+		s.despertarMatchmaking()
+		return &pb.QueuePlayerResponse{
+			Message:     fmt.Sprintf("Grupo %d agregado a la cola %s", entry.PartyID, mode),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
 
-	log.Printf("[Matchmaker] Jugador %d agregado a la cola", playerID)
+	log.Printf("[Matchmaker] Jugador %d agregado a la cola %s", playerID, mode)
+	s.despertarMatchmaking()
 
 	return &pb.QueuePlayerResponse{
-		Message:     "Jugador agregado a la cola",
-		VectorClock: &pb.VectorClock{Clocks: s.vectorClock},
+		Message:     fmt.Sprintf("Jugador agregado a la cola %s", mode),
+		VectorClock: s.relojProto(),
 	}, nil
 }
 
 func (s *server) GetPlayerStatus(ctx context.Context, req *pb.PlayerStatusRequest) (*pb.PlayerStatusResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("GetPlayerStatus del jugador %d", req.PlayerId), req.VectorClock)
 
 	status := s.playerStatus[req.PlayerId]
 	if status == "" {
 		status = "IDLE"
 	}
 
-	return &pb.PlayerStatusResponse{
-		Status:             status,
-		MatchId:            0, // se puede agregar lógica para devolver info real
-		MatchServerAddress: "",
-		VectorClock:        &pb.VectorClock{Clocks: s.vectorClock},
+	var restante int32
+	if rc, ok := s.readyChecks[s.playerMatch[req.PlayerId]]; ok && status == "MATCH FOUND" {
+		restante = int32(math.Ceil(time.Until(rc.Deadline).Seconds()))
+	}
+
+	res := &pb.PlayerStatusResponse{
+		Status:                status,
+		VectorClock:           s.relojProto(),
+		Rating:                int32(math.Round(s.ratingDe(req.PlayerId))),
+		PartyId:               s.playerParty[req.PlayerId],
+		ReadyCheckSecondsLeft: restante,
+	}
+	if match, ok := s.partidaDe(req.PlayerId); ok {
+		res.MatchId = match.ID
+		res.MatchServerAddress = match.ServerAddress
+		res.Match = match.toProto()
+	}
+	return res, nil
+}
+
+func (s *server) LeaveQueue(ctx context.Context, req *pb.LeaveQueueRequest) (*pb.LeaveQueueResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("LeaveQueue del jugador %d", req.PlayerId), req.VectorClock)
+
+	playerID := req.PlayerId
+	mode, enCola := s.playerMode[playerID]
+	if !enCola {
+		// Salir durante el ready-check equivale a rechazar la partida
+		if rc, ok := s.readyChecks[s.playerMatch[playerID]]; ok {
+			log.Printf("[Matchmaker] Jugador %d salió de la cola durante el ready-check de la partida %d", playerID, rc.MatchID)
+			s.fallarReadyCheck(rc, map[int32]bool{playerID: true})
+			return &pb.LeaveQueueResponse{
+				StatusCode:  "SUCCESS",
+				Message:     fmt.Sprintf("Partida %d rechazada. Saliste de la cola", rc.MatchID),
+				VectorClock: s.relojProto(),
+			}, nil
+		}
+
+		// Si su partida ya se formó y se está asignando, se marca para que no
+		// vuelva a la cola en caso de que la asignación falle
+		if match, ok := s.partidaDe(playerID); ok && match.Status == "EN CURSO" {
+			s.cancelados[playerID] = true
+			s.marcar("meta")
+			log.Printf("[Matchmaker] Jugador %d canceló la búsqueda durante la asignación de la partida %d", playerID, match.ID)
+			return &pb.LeaveQueueResponse{
+				StatusCode:  "FAILURE",
+				Message:     fmt.Sprintf("El jugador ya fue emparejado en la partida %d", match.ID),
+				VectorClock: s.relojProto(),
+			}, nil
+		}
+		return &pb.LeaveQueueResponse{
+			StatusCode:  "FAILURE",
+			Message:     "El jugador no está en cola",
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	// Se quita la entrada completa: si el jugador está en un grupo, todo el
+	// grupo sale de la cola
+	queue := s.playersQueue[mode]
+	for i, entry := range queue {
+		if !contiene(entry.Players, playerID) {
+			continue
+		}
+		_, s.playersQueue[mode] = quitarDeCola(queue, []int{i})
+		for _, id := range entry.Players {
+			delete(s.playerMode, id)
+			s.playerStatus[id] = "IDLE"
+		}
+		s.marcar("cola/" + mode)
+		s.marcarJugadores(entry.Players...)
+
+		msg := fmt.Sprintf("Jugador salió de la cola %s", mode)
+		if entry.PartyID != 0 {
+			msg = fmt.Sprintf("Grupo %d salió de la cola %s", entry.PartyID, mode)
+		}
+		log.Printf("[Matchmaker] %s (jugador %d)", msg, playerID)
+
+		return &pb.LeaveQueueResponse{
+			StatusCode:  "SUCCESS",
+			Message:     msg,
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	return &pb.LeaveQueueResponse{
+		StatusCode:  "FAILURE",
+		Message:     "El jugador no está en cola",
+		VectorClock: s.relojProto(),
 	}, nil
 }
 
 func (s *server) UpdateServerStatus(ctx context.Context, req *pb.ServerStatusUpdateRequest) (*pb.ServerStatusUpdateResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.reincorporarMiembro(req.ServerId, req.VectorClock)
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("UpdateServerStatus de %s: %s", req.ServerId, req.NewStatus), req.VectorClock)
+	entrante := s.relojRecibido(req.VectorClock)
+
+	// Un mensaje atrasado no puede pisar un estado más reciente del servidor
+	aplicar, vc := s.ordenarActualizacion(req.ServerId, req.NewStatus, entrante)
+	if !aplicar {
+		return &pb.ServerStatusUpdateResponse{
+			StatusCode:  "FAILURE",
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	// Los servidores que no informan capacidad cuentan como capacidad 1
+	capacidad := req.Capacity
+	if capacidad <= 0 {
+		capacidad = 1
+	}
 
-	s.mergeVectorClock(req.VectorClock.Clocks)
-	s.vectorClock["Matchmaker"]++
+	// Un DRAINING pedido por el administrador se mantiene aunque el servidor
+	// informe que quedó libre, hasta que se dé de baja
+	nuevoEstado := req.NewStatus
+	if anterior, ok := s.gameServers[req.ServerId]; ok && anterior.Status == "DRAINING" && nuevoEstado == "DISPONIBLE" {
+		nuevoEstado = "DRAINING"
+	}
 
 	s.gameServers[req.ServerId] = &GameServerInfo{
 		ID:         req.ServerId,
 		Address:    req.Address,
-		Status:     req.NewStatus,
+		Status:     nuevoEstado,
+		Capacity:   capacidad,
 		LastUpdate: time.Now(),
+		HLC:        s.hlc.Actual(),
+		VC:         vc,
+	}
+	s.marcarServidor(req.ServerId)
+	// Informar el estado también cuenta como renovación del lease
+	s.renovarLease(req.ServerId, 0)
+
+	log.Printf("[Matchmaker] Estado de %s actualizado a %s (reloj %v, HLC %s)", req.ServerId, nuevoEstado, entrante, reloj.MarcaDesdeProto(req.VectorClock))
+	switch nuevoEstado {
+	case "DISPONIBLE":
+		s.despertarMatchmaking()
+	case "CAIDO":
+		s.interrumpirPartida(req.ServerId)
 	}
-
-	log.Printf("[Matchmaker] Estado de %s actualizado a %s", req.ServerId, req.NewStatus)
 
 	return &pb.ServerStatusUpdateResponse{
 		StatusCode:  "SUCCESS",
-		VectorClock: &pb.VectorClock{Clocks: s.vectorClock},
+		VectorClock: s.relojProto(),
 	}, nil
 }
 
 // =================== FUNCIONES AUXILIARES ====================
 
-func (s *server) matchmakingLoop() {
-	for {
-		time.Sleep(2 * time.Second)
-
-		s.mu.Lock()
-		if len(s.playersQueue) >= 2 {
-			var availableServer *GameServerInfo
-			for _, srv := range s.gameServers {
-				if srv.Status == "DISPONIBLE" {
-					availableServer = srv
-					break
-				}
+// Registra un evento del Matchmaker: incorpora el reloj vectorial y la marca
+// HLC del remitente, si es una recepción (nil si no lo es), avanza los relojes
+// del Matchmaker y anota el evento en el registro causal de la réplica.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) registrarEvento(tipo reloj.Tipo, evento string, remoto *pb.VectorClock) {
+	vc := s.relojRecibido(remoto)
+	s.registrarMiembros(vc)
+	s.vectorClock.Fusionar(vc)
+	s.vectorClock.Incrementar("Matchmaker")
+	marca := s.hlc.Recibir(reloj.MarcaDesdeProto(remoto))
+	s.eventos.Anotar(tipo, evento, s.vectorClock, marca)
+}
+
+// Decide si se aplica una actualización de estado de un servidor comparando
+// su reloj con el de la última que se aplicó, y devuelve el reloj que queda
+// como último aplicado. Se descartan las que causalmente la preceden: por
+// ejemplo, un OCUPADO demorado que llega después del DISPONIBLE que lo siguió.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) ordenarActualizacion(id, estado string, entrante reloj.Reloj) (bool, reloj.Reloj) {
+	// Un mensaje demorado de un servidor que ya se dio de baja no lo vuelve
+	// a registrar
+	if s.dadoDeBaja(id) {
+		log.Printf("[Matchmaker] Actualización de %s a %s descartada: el servidor se dio de baja", id, estado)
+		return false, nil
+	}
+
+	anterior, ok := s.gameServers[id]
+	// Un servidor que todavía no supo nada del Matchmaker es un proceso nuevo
+	// que se registra (por ejemplo, tras reiniciarse): no puede estar atrasado
+	if !ok || anterior.VC == nil || entrante["Matchmaker"] == 0 {
+		return true, entrante
+	}
+
+	switch reloj.Comparar(entrante, anterior.VC) {
+	case reloj.Antes:
+		log.Printf("[Matchmaker] Actualización de %s a %s descartada: su reloj %v precede al del último estado aplicado %v",
+			id, estado, entrante, anterior.VC)
+		return false, anterior.VC
+	case reloj.Concurrente:
+		// Los eventos del propio servidor sí están ordenados entre sí: gana la
+		// actualización que conoce más eventos suyos
+		aplicar := entrante[id] >= anterior.VC[id]
+		decision := "se descarta"
+		if aplicar {
+			decision = "se aplica"
+		}
+		log.Printf("[Matchmaker] Actualización de %s a %s concurrente con el último estado aplicado (%v y %v): %s",
+			id, estado, entrante, anterior.VC, decision)
+		if !aplicar {
+			return false, anterior.VC
+		}
+		vc := anterior.VC.Copia()
+		vc.Fusionar(entrante)
+		return true, vc
+	}
+	return true, entrante
+}
+
+// Forma todas las partidas posibles con los jugadores en cola y los
+// servidores disponibles. Termina cuando no quedan servidores libres o
+// ninguna cola tiene un grupo compatible.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) emparejar() {
+	ahora := time.Now()
+	for _, cfg := range gameModes {
+		mode := cfg.Name
+		for {
+			queue := s.playersQueue[mode]
+			grupo := s.buscarGrupo(queue, cfg, ahora)
+			if grupo == nil {
+				break
 			}
 
-			if availableServer != nil {
-				p1 := s.playersQueue[0]
-				p2 := s.playersQueue[1]
-				s.playersQueue = s.playersQueue[2:]
+			availableServer := s.elegirServidor()
+			if availableServer == nil {
+				return
+			}
+
+			s.registrarEvento(reloj.Local, fmt.Sprintf("Partida encontrada en %s (%s)", availableServer.ID, mode), nil)
+
+			var entries []*queueEntry
+			entries, s.playersQueue[mode] = quitarDeCola(queue, grupo)
+			s.marcar("cola/" + mode)
+			teams, _ := s.armarEquipos(entries, cfg)
+			match := s.registrarPartida(mode, availableServer, teams)
+
+			log.Printf("[Matchmaker] Partida encontrada: equipos %v (%s) en %s (MatchID: %d). Esperando confirmación",
+				teams, mode, availableServer.ID, match.ID)
 
-				s.playerStatus[p1] = "IN MATCH"
-				s.playerStatus[p2] = "IN MATCH"
+			// Los jugadores deben aceptar antes de asignar la partida; el
+			// servidor queda reservado mientras tanto
+			s.iniciarReadyCheck(&readyCheck{MatchID: match.ID, Entries: entries})
 
-				matchID := s.nextMatchID
-				s.nextMatchID++
-				s.vectorClock["Matchmaker"]++
+			availableServer.Status = "OCUPADO"
+			s.marcarServidor(availableServer.ID)
+		}
+	}
+}
+
+// Envía la partida al servidor de juego, que solo confirma que la recibió. El
+// resultado llega después con ReportMatchResult. Si el servidor no confirma,
+// la partida pasa a otro servidor disponible (ver fallarAsignacion).
+// fallos cuenta los servidores que ya fallaron con esta partida.
+//
+// El pedido se arma en el bucle de eventos y se envía desde otra goroutine;
+// la respuesta vuelve al bucle como una orden más.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) enviarAssignMatch(gs *GameServerInfo, match *Match, entries []*queueEntry, fallos int) {
+	id, addr := gs.ID, gs.Address
+	s.registrarEvento(reloj.Envio, fmt.Sprintf("AssignMatch de la partida %d a %s", match.ID, id), nil)
+	req := &pb.AssignMatchRequest{
+		MatchId:     match.ID,
+		VectorClock: s.relojProto(),
+		GameMode:    match.Mode,
+		Teams:       match.toProto().Teams,
+	}
 
-				log.Printf("[Matchmaker] Emparejando %d vs %d en %s (MatchID: %d)", p1, p2, availableServer.ID, matchID)
+	go func() {
+		res, err := s.asignarConReintentos(id, addr, req)
+		s.ejecutar(func() {
+			if !s.partidaVigente(match) || match.Status != "EN CURSO" || match.ServerID != id {
+				return
+			}
+			if err != nil {
+				s.fallarAsignacion(gs, match, entries, fallos, err)
+				return
+			}
+			s.registrarEvento(reloj.Recepcion, fmt.Sprintf("%s confirmó la partida %d", id, match.ID), res.VectorClock)
+			match.Recibida = true
+			s.marcarPartida(match.ID)
+			log.Printf("[Matchmaker] %s recibió la partida %d", id, match.ID)
+		})
+	}()
+}
 
-				go s.enviarAssignMatch(availableServer, matchID, []int32{p1, p2})
+// Indica si la partida sigue en el estado actual del Matchmaker. Deja de
+// estarlo si esta réplica perdió el liderazgo mientras se jugaba: el estado se
+// reconstruyó desde el log y la partida ya se cerró allí.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) partidaVigente(match *Match) bool {
+	return s.sirviendo.Load() && s.matches[match.ID] == match
+}
 
-				availableServer.Status = "OCUPADO"
+// Devuelve a la cabeza de la cola las entradas de una partida que no se
+// concretó, en su orden original. Las entradas con algún jugador excluido
+// salen completas de la cola (un grupo nunca se separa).
+// Debe llamarse desde el bucle de eventos.
+func (s *server) devolverACola(mode string, entries []*queueEntry, excluidos map[int32]bool) {
+	var requeue []*queueEntry
+	for _, entry := range entries {
+		excluido := false
+		for _, id := range entry.Players {
+			excluido = excluido || excluidos[id]
+			delete(s.playerMatch, id)
+		}
+		s.marcarJugadores(entry.Players...)
+		for _, id := range entry.Players {
+			if excluido {
+				s.playerStatus[id] = "IDLE"
+			} else {
+				s.playerMode[id] = mode
+				s.playerStatus[id] = "IN QUEUE"
 			}
 		}
-		s.mu.Unlock()
+		if !excluido {
+			requeue = append(requeue, entry)
+		}
 	}
+	s.playersQueue[mode] = append(requeue, s.playersQueue[mode]...)
+	s.marcar("cola/" + mode)
+	s.despertarMatchmaking()
 }
 
-func (s *server) enviarAssignMatch(gs *GameServerInfo, matchID int32, players []int32) {
-	conn, err := grpc.Dial(gs.Address, grpc.WithInsecure())
-	if err != nil {
-		log.Printf("[Matchmaker] Error conectando a %s: %v", gs.ID, err)
-		return
+// Quita de la cola las entradas en las posiciones indicadas (en orden
+// creciente). Devuelve las entradas quitadas y el resto de la cola, que
+// mantiene el orden de llegada.
+func quitarDeCola(queue []*queueEntry, idx []int) ([]*queueEntry, []*queueEntry) {
+	quitados := make([]*queueEntry, 0, len(idx))
+	rest := make([]*queueEntry, 0, len(queue)-len(idx))
+	for k, entry := range queue {
+		if len(quitados) < len(idx) && idx[len(quitados)] == k {
+			quitados = append(quitados, entry)
+		} else {
+			rest = append(rest, entry)
+		}
 	}
-	defer conn.Close()
+	return quitados, rest
+}
 
-	client := pb.NewComunicacionServiceClient(conn)
+// Devuelve el modo de juego canónico para la preferencia recibida.
+// Una preferencia vacía se asigna al modo por defecto.
+func normalizarModo(pref string) (string, bool) {
+	if pref == "" {
+		return defaultGameMode, true
+	}
+	for _, mode := range gameModes {
+		if strings.EqualFold(mode.Name, pref) {
+			return mode.Name, true
+		}
+	}
+	return "", false
+}
 
-	_, err = client.AssignMatch(context.Background(), &pb.AssignMatchRequest{
-		MatchId:     matchID,
-		PlayersIds:  players,
-		VectorClock: &pb.VectorClock{Clocks: s.vectorClock},
-	})
-	if err != nil {
-		log.Printf("[Matchmaker] Error asignando partida en %s: %v", gs.ID, err)
-		s.mu.Lock()
-		defer s.mu.Unlock()
-		gs.Status = "CAIDO"
-		s.playersQueue = append([]int32{players[0], players[1]}, s.playersQueue...)
+// Indica si el jugador está en la lista.
+func contiene(players []int32, playerID int32) bool {
+	for _, id := range players {
+		if id == playerID {
+			return true
+		}
 	}
+	return false
 }
 
-func (s *server) mergeVectorClock(remote map[string]int32) {
-	for k, v := range remote {
-		if local, ok := s.vectorClock[k]; !ok || v > local {
-			s.vectorClock[k] = v
+// Devuelve la configuración de un modo de juego ya normalizado.
+func modoDe(mode string) modeConfig {
+	for _, cfg := range gameModes {
+		if cfg.Name == mode {
+			return cfg
 		}
 	}
+	return modeConfig{}
 }
 
 //// posiblemente borrar dsp
 
 func (s *server) AdminGetSystemStatus(ctx context.Context, req *pb.AdminRequest) (*pb.SystemStatusResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
+	ahora := time.Now()
 	var servers []*pb.ServerState
 	for _, gs := range s.gameServers {
+		var vence string
+		if l, ok := s.leases[gs.ID]; ok {
+			vence = l.Vence.Format(time.RFC3339)
+		}
 		servers = append(servers, &pb.ServerState{
-			Id:             gs.ID,
-			Status:         gs.Status,
-			Address:        gs.Address,
-			CurrentMatchId: 0, // o real si tienes control de partidas
+			Id:              gs.ID,
+			Status:          gs.Status,
+			Address:         gs.Address,
+			CurrentMatchId:  s.serverMatch[gs.ID],
+			Capacity:        gs.Capacity,
+			MatchesAssigned: s.asignadas[gs.ID],
+			LastUpdate:      gs.LastUpdate.Format(time.RFC3339),
+			LastUpdateHlc:   gs.HLC.String(),
+			LeaseExpired:    !s.leaseVigente(gs.ID, ahora),
+			LeaseExpiresAt:  vence,
 		})
 	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Id < servers[j].Id })
+
+	var matches []*pb.MatchInfo
+	for id := int32(1); id < s.nextMatchID; id++ {
+		if match, ok := s.matches[id]; ok && match.activa() {
+			matches = append(matches, match.toProto())
+		}
+	}
 
 	var queue []*pb.PlayerQueueEntry
-	for _, playerID := range s.playersQueue {
-		queue = append(queue, &pb.PlayerQueueEntry{
-			PlayerId:    playerID,
-			TimeInQueue: "0s", // valor fijo o calculado
-		})
+	var modeQueues []*pb.GameModeQueue
+	for _, cfg := range gameModes {
+		mode := cfg.Name
+		modeQueue := &pb.GameModeQueue{GameMode: mode}
+		for _, qe := range s.playersQueue[mode] {
+			for _, playerID := range qe.Players {
+				entry := &pb.PlayerQueueEntry{
+					PlayerId:    playerID,
+					TimeInQueue: tiempoEnCola(qe, ahora),
+					GameMode:    mode,
+					Rating:      int32(math.Round(s.ratingDe(playerID))),
+					PartyId:     qe.PartyID,
+				}
+				queue = append(queue, entry)
+				modeQueue.Players = append(modeQueue.Players, entry)
+			}
+		}
+		modeQueues = append(modeQueues, modeQueue)
 	}
 
 	return &pb.SystemStatusResponse{
-		Servers:     servers,
-		PlayerQueue: queue,
-		VectorClock: &pb.VectorClock{Clocks: s.vectorClock},
+		Servers:           servers,
+		PlayerQueue:       queue,
+		VectorClock:       s.relojProto(),
+		GameModeQueues:    modeQueues,
+		Matches:           matches,
+		SelectionStrategy: s.selector.Nombre(),
+		Connections:       s.conexiones.estados(),
 	}, nil
 }
 
 func (s *server) AdminUpdateServerState(ctx context.Context, req *pb.AdminServerUpdateRequest) (*pb.AdminUpdateResponse, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	server, ok := s.gameServers[req.ServerId]
 	if !ok {
 		return &pb.AdminUpdateResponse{
@@ -222,8 +665,17 @@ func (s *server) AdminUpdateServerState(ctx context.Context, req *pb.AdminServer
 
 	server.Status = req.NewForcedStatus
 	server.LastUpdate = time.Now()
+	server.HLC = s.hlc.Actual()
+	server.SinRespuesta = false
+	s.marcarServidor(server.ID)
 
+	// Un servidor en DRAINING no recibe partidas nuevas; termina la actual y
+	// se entera de que debe retirarse al renovar su lease
 	log.Printf("[Admin] Estado forzado de %s a %s", server.ID, server.Status)
+	if server.Status == "CAIDO" {
+		s.interrumpirPartida(server.ID)
+	}
+	s.despertarMatchmaking()
 
 	return &pb.AdminUpdateResponse{
 		StatusCode: "SUCCESS",
@@ -234,26 +686,121 @@ func (s *server) AdminUpdateServerState(ctx context.Context, req *pb.AdminServer
 // ===================== MAIN =========================
 
 func main() {
-	lis, err := net.Listen("tcp", address)
+	id := flag.String("id", "1", "ID de esta réplica del Matchmaker")
+	cluster := flag.String("cluster", "",
+		"réplicas del cluster como id=dirección separadas por comas (ej: 1=localhost:50051,2=localhost:50052,3=localhost:50053); vacío para una sola réplica en "+address)
+	estrategia := flag.String("seleccion", seleccionRoundRobin,
+		"estrategia de selección de servidor: round-robin, lru, ponderada o aleatoria")
+	semilla := flag.Int64("semilla", time.Now().UnixNano(), "semilla de la selección aleatoria")
+	datos := flag.String("datos", "", "directorio del WAL y los snapshots del estado (por defecto datos_matchmaker, o datos_matchmaker_<id> en un cluster)")
+	eventos := flag.String("eventos", "", "registro causal de eventos (por defecto eventos_matchmaker.jsonl, o eventos_matchmaker_<id>.jsonl en un cluster)")
+	flag.Parse()
+
+	selector, err := nuevoSelector(*estrategia, *semilla)
+	if err != nil {
+		log.Fatalf("Configuración inválida: %v", err)
+	}
+	peers, err := parsearCluster(*cluster, *id)
+	if err != nil {
+		log.Fatalf("Configuración inválida: %v", err)
+	}
+	if *datos == "" {
+		*datos = "datos_matchmaker"
+		if len(peers) > 1 {
+			*datos += "_" + *id
+		}
+	}
+	if *eventos == "" {
+		*eventos = "eventos_matchmaker"
+		if len(peers) > 1 {
+			*eventos += "_" + *id
+		}
+		*eventos += ".jsonl"
+	}
+	registro, err := reloj.AbrirRegistro("Matchmaker", *eventos)
+	if err != nil {
+		log.Fatalf("Error al abrir el registro de eventos %s: %v", *eventos, err)
+	}
+
+	lis, err := net.Listen("tcp", peers[*id])
 	if err != nil {
 		log.Fatalf("Error al escuchar: %v", err)
 	}
-	s := grpc.NewServer()
 
-	srv := &server{
-		playersQueue: make([]int32, 0),
-		playerStatus: make(map[int32]string),
-		playerVC:     make(map[int32]map[string]int32),
-		gameServers:  make(map[string]*GameServerInfo),
-		vectorClock:  map[string]int32{"Matchmaker": 0, "Player1": 0, "Player2": 0, "GameServer1": 0},
-		nextMatchID:  1,
+	srv, s, err := nuevaReplica(*id, peers, *datos, selector, registro)
+	if err != nil {
+		log.Fatalf("Error al abrir el estado persistido en %s: %v", *datos, err)
 	}
+	// Cada línea del log lleva la marca HLC del último evento de la réplica
+	log.SetOutput(srv.hlc.Escritor(os.Stderr))
+	srv.iniciar()
 
-	go srv.matchmakingLoop()
+	listo := make(chan struct{})
+	go srv.esperarApagado(s, listo)
 
-	pb.RegisterComunicacionServiceServer(s, srv)
-	fmt.Println("[Matchmaker] Servidor escuchando en", address)
+	fmt.Printf("[Matchmaker] Réplica %s escuchando en %s (%d réplicas en el cluster)\n", *id, peers[*id], len(peers))
+	fmt.Println("[Matchmaker] Estrategia de selección de servidor:", selector.Nombre())
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Error al servir: %v", err)
 	}
+	<-listo
+}
+
+// Crea una réplica del Matchmaker con el estado persistido en el directorio
+// datos y el servidor gRPC que la atiende. La réplica empieza a participar
+// del cluster con iniciar.
+func nuevaReplica(id string, peers map[string]string, datos string, selector selectorServidor, registro *reloj.Registro) (*server, *grpc.Server, error) {
+	srv := &server{
+		selector:   selector,
+		ordenes:    make(chan orden),
+		avisos:     make(chan struct{}, 1),
+		enCurso:    make(map[string]chan struct{}),
+		conexiones: nuevasConexiones(),
+		eventos:    registro,
+	}
+	srv.reiniciarEstado()
+
+	// El log y el snapshot locales se recuperan antes de unirse al cluster
+	alm, snap, estado, entradas, err := abrirAlmacen(datos)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("[Matchmaker] Réplica %s: snapshot hasta el índice %d, %d entradas en el log, término %d",
+		id, snap.LastIndex, len(entradas), estado.Term)
+	srv.raft = nuevoNodoRaft(id, peers, alm, srv.conexiones, snap, estado, entradas)
+	srv.raft.alSerLider = func(term int64, img imagenEstado) {
+		srv.ejecutar(func() { srv.asumirLiderazgo(term, img) })
+	}
+	srv.raft.alDejarLider = func() { srv.ejecutar(srv.dejarLiderazgo) }
+
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(srv.interceptorLider, srv.interceptorIdempotencia))
+	pb.RegisterComunicacionServiceServer(s, &servicio{s: srv})
+	return srv, s, nil
+}
+
+// Arranca la réplica: Raft, el bucle de eventos y el chequeo de salud.
+func (s *server) iniciar() {
+	s.raft.iniciar()
+	go s.bucleEventos()
+	go s.healthCheckLoop()
+}
+
+// Interpreta el flag -cluster. Sin cluster, la única réplica escucha en
+// address.
+func parsearCluster(cluster, id string) (map[string]string, error) {
+	if cluster == "" {
+		return map[string]string{id: address}, nil
+	}
+	peers := make(map[string]string)
+	for _, par := range strings.Split(cluster, ",") {
+		peer, addr, ok := strings.Cut(strings.TrimSpace(par), "=")
+		if !ok || peer == "" || addr == "" {
+			return nil, fmt.Errorf("réplica inválida en -cluster: %q (se espera id=dirección)", par)
+		}
+		peers[peer] = addr
+	}
+	if _, ok := peers[id]; !ok {
+		return nil, fmt.Errorf("la réplica %s no está en -cluster", id)
+	}
+	return peers, nil
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Tiempo que se conserva el componente de un servidor dado de baja antes de
+// podarlo de los relojes. Da margen a los mensajes que todavía estén en
+// camino con su valor.
+const podaEspera = 30 * time.Second
+
+// Proceso que participa de los relojes vectoriales. Los procesos se
+// incorporan cuando su componente aparece en un reloj recibido, y los
+// servidores que se dan de baja se podan pasado podaEspera. Los jugadores no
+// avisan cuando se van, así que su componente se conserva.
+type miembro struct {
+	Alta   time.Time
+	Baja   time.Time // cero mientras el proceso sigue activo
+	Podado bool      // su componente ya se quitó de los relojes
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Incorpora a los procesos del reloj que todavía no se conocían.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) registrarMiembros(vc reloj.Reloj) {
+	for id := range vc {
+		// El Matchmaker no se da de baja: no necesita seguimiento
+		if id == "Matchmaker" {
+			continue
+		}
+		if _, ok := s.miembros[id]; !ok {
+			s.miembros[id] = &miembro{Alta: time.Now()}
+			s.marcar("miembro/" + id)
+			log.Printf("[Matchmaker] %s se incorpora a los relojes vectoriales", id)
+		}
+	}
+}
+
+// Marca la baja de un servidor. Su componente se poda pasado podaEspera.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) darDeBajaMiembro(id string) {
+	m, ok := s.miembros[id]
+	if !ok {
+		m = &miembro{Alta: time.Now()}
+		s.miembros[id] = m
+	}
+	m.Baja = time.Now()
+	s.marcar("miembro/" + id)
+}
+
+// Un servidor dado de baja que se registra de nuevo sin conocer nada del
+// Matchmaker es otra ejecución del proceso: vuelve a ser un miembro activo.
+// Si su componente no se podó todavía, la nueva ejecución lo continúa al
+// recibir la primera respuesta; si se podó, empieza de nuevo desde 0.
+// Debe llamarse desde el bucle de eventos, antes de relojRecibido.
+func (s *server) reincorporarMiembro(id string, vc *pb.VectorClock) {
+	m, ok := s.miembros[id]
+	if !ok || m.Baja.IsZero() || vc.GetClocks()["Matchmaker"] != 0 {
+		return
+	}
+	log.Printf("[Matchmaker] %s vuelve a incorporarse a los relojes vectoriales", id)
+	s.miembros[id] = &miembro{Alta: time.Now()}
+	s.marcar("miembro/" + id)
+}
+
+// Indica si el servidor se dio de baja y no volvió a registrarse.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) dadoDeBaja(id string) bool {
+	m, ok := s.miembros[id]
+	return ok && !m.Baja.IsZero()
+}
+
+// Poda el componente de los servidores que se dieron de baja hace más de
+// podaEspera: se quita del reloj del Matchmaker y de todos los relojes que
+// guarda para comparar. Como a partir de ahí también se quita de los relojes
+// recibidos (ver relojRecibido), ningún reloj que se compare conserva el
+// componente y las comparaciones dan lo mismo que antes de podar.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) podarMiembros(ahora time.Time) {
+	for id, m := range s.miembros {
+		if m.Baja.IsZero() || m.Podado || ahora.Sub(m.Baja) < podaEspera {
+			continue
+		}
+		s.vectorClock.Quitar(id)
+		for _, gs := range s.gameServers {
+			gs.VC.Quitar(id)
+			s.marcarServidor(gs.ID)
+		}
+		for jugador, vc := range s.playerVC {
+			vc.Quitar(id)
+			s.marcarJugadores(jugador)
+		}
+		m.Podado = true
+		s.marcar("miembro/" + id)
+		log.Printf("[Matchmaker] Componente de %s podado de los relojes vectoriales (se dio de baja hace %s)",
+			id, ahora.Sub(m.Baja).Round(time.Second))
+	}
+}
+
+// Procesos podados, en orden. Viajan en cada reloj que envía el Matchmaker
+// para que los demás procesos también los olviden.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) podados() []string {
+	var ids []string
+	for id, m := range s.miembros {
+		if m.Podado {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Reloj recibido en un mensaje, sin los componentes podados: un proceso que
+// todavía no se enteró de la poda puede seguir enviándolos.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) relojRecibido(vc *pb.VectorClock) reloj.Reloj {
+	r := reloj.DesdeProto(vc)
+	r.Quitar(s.podados()...)
+	return r
+}
+
+// Copia del reloj del Matchmaker para enviarla en un mensaje, con la lista de
+// procesos podados y la marca HLC del último evento.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) relojProto() *pb.VectorClock {
+	return &pb.VectorClock{Clocks: s.vectorClock.Copia(), Pruned: s.podados(), HybridClock: s.hlc.Actual().Proto()}
+}
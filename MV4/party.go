@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Tamaño máximo de un grupo (el de un equipo en el modo más grande)
+const maxPartySize = 5
+
+// Grupo de jugadores que entra junto a la cola. El líder es el único que
+// puede ponerlo en cola y el matchmaker nunca lo separa entre partidas.
+type Party struct {
+	ID      int32
+	Leader  int32
+	Members []int32
+}
+
+// ===================== RPCS =========================
+
+func (s *server) CreateParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("CreateParty del jugador %d", req.PlayerId), req.VectorClock)
+
+	playerID := req.PlayerId
+	if party, enGrupo := s.partyDe(playerID); enGrupo {
+		return s.partyResponse("FAILURE", fmt.Sprintf("El jugador ya pertenece al grupo %d", party.ID), party), nil
+	}
+	if _, enCola := s.playerMode[playerID]; enCola {
+		return s.partyResponse("FAILURE", "No se puede crear un grupo estando en cola", nil), nil
+	}
+	if s.enPartida(playerID) {
+		return s.partyResponse("FAILURE", "No se puede crear un grupo durante una partida", nil), nil
+	}
+
+	party := &Party{ID: s.nextPartyID, Leader: playerID, Members: []int32{playerID}}
+	s.nextPartyID++
+	s.parties[party.ID] = party
+	s.playerParty[playerID] = party.ID
+	s.marcar("meta")
+	s.marcarGrupo(party.ID)
+	s.marcarJugadores(playerID)
+
+	log.Printf("[Matchmaker] Jugador %d creó el grupo %d", playerID, party.ID)
+
+	return s.partyResponse("SUCCESS", fmt.Sprintf("Grupo %d creado", party.ID), party), nil
+}
+
+func (s *server) JoinParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("JoinParty del jugador %d al grupo %d", req.PlayerId, req.PartyId), req.VectorClock)
+
+	playerID := req.PlayerId
+	if actual, enGrupo := s.partyDe(playerID); enGrupo {
+		return s.partyResponse("FAILURE", fmt.Sprintf("El jugador ya pertenece al grupo %d", actual.ID), actual), nil
+	}
+	if _, enCola := s.playerMode[playerID]; enCola {
+		return s.partyResponse("FAILURE", "No se puede unir a un grupo estando en cola", nil), nil
+	}
+	if s.enPartida(playerID) {
+		return s.partyResponse("FAILURE", "No se puede unir a un grupo durante una partida", nil), nil
+	}
+
+	party, ok := s.parties[req.PartyId]
+	if !ok {
+		return s.partyResponse("FAILURE", "Grupo no encontrado", nil), nil
+	}
+	if s.partyEnCola(party) {
+		return s.partyResponse("FAILURE", "El grupo está en cola o en partida", party), nil
+	}
+	if len(party.Members) >= maxPartySize {
+		return s.partyResponse("FAILURE", fmt.Sprintf("El grupo está lleno (máximo %d jugadores)", maxPartySize), party), nil
+	}
+
+	party.Members = append(party.Members, playerID)
+	s.playerParty[playerID] = party.ID
+	s.marcarGrupo(party.ID)
+	s.marcarJugadores(playerID)
+
+	log.Printf("[Matchmaker] Jugador %d se unió al grupo %d %v", playerID, party.ID, party.Members)
+
+	return s.partyResponse("SUCCESS", fmt.Sprintf("Unido al grupo %d", party.ID), party), nil
+}
+
+func (s *server) LeaveParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("LeaveParty del jugador %d", req.PlayerId), req.VectorClock)
+
+	playerID := req.PlayerId
+	party, enGrupo := s.partyDe(playerID)
+	if !enGrupo {
+		return s.partyResponse("FAILURE", "El jugador no pertenece a ningún grupo", nil), nil
+	}
+	if s.partyEnCola(party) {
+		return s.partyResponse("FAILURE", "No se puede salir del grupo mientras está en cola o en partida", party), nil
+	}
+
+	s.quitarDeParty(party, playerID)
+
+	log.Printf("[Matchmaker] Jugador %d salió del grupo %d", playerID, party.ID)
+
+	return s.partyResponse("SUCCESS", fmt.Sprintf("Saliste del grupo %d", party.ID), nil), nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Devuelve el grupo del jugador, si pertenece a uno.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) partyDe(playerID int32) (*Party, bool) {
+	party, ok := s.parties[s.playerParty[playerID]]
+	return party, ok
+}
+
+// Indica si algún miembro del grupo está en cola o en una partida activa.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) partyEnCola(party *Party) bool {
+	for _, id := range party.Members {
+		if _, enCola := s.playerMode[id]; enCola || s.enPartida(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Quita a un jugador del grupo. Si era el líder, el liderazgo pasa al miembro
+// más antiguo; si el grupo queda vacío, se elimina.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) quitarDeParty(party *Party, playerID int32) {
+	for i, id := range party.Members {
+		if id == playerID {
+			party.Members = append(party.Members[:i:i], party.Members[i+1:]...)
+			break
+		}
+	}
+	delete(s.playerParty, playerID)
+	s.marcarGrupo(party.ID)
+	s.marcarJugadores(playerID)
+
+	if len(party.Members) == 0 {
+		delete(s.parties, party.ID)
+		return
+	}
+	if party.Leader == playerID {
+		party.Leader = party.Members[0]
+		log.Printf("[Matchmaker] Jugador %d es el nuevo líder del grupo %d", party.Leader, party.ID)
+	}
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) partyResponse(code, msg string, party *Party) *pb.PartyResponse {
+	res := &pb.PartyResponse{
+		StatusCode:  code,
+		Message:     msg,
+		VectorClock: s.relojProto(),
+	}
+	if party != nil {
+		res.PartyId = party.ID
+		res.LeaderId = party.Leader
+		res.MembersIds = append([]int32(nil), party.Members...)
+	}
+	return res
+}
@@ -0,0 +1,786 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"MV4/reloj"
+)
+
+// Archivos del estado durable de cada réplica del Matchmaker
+const (
+	walArchivo      = "wal.log"
+	snapshotArchivo = "snapshot.json"
+	snapshotCada    = 200 // entradas aplicadas entre snapshots
+)
+
+// Las pruebas lo activan para verificar después de cada paso del bucle de
+// eventos que no quedó ningún cambio sin marcar (ver comprobarMarcas).
+var comprobarCambios bool
+
+// El estado del Matchmaker se representa como una imagen: un mapa de
+// entidades (jugadores, colas, partidas, servidores, grupos...) a su valor en
+// JSON. Las funciones que modifican el estado marcan las entidades que tocan,
+// y después de cada paso del bucle de eventos el líder agrega al log de Raft
+// un registro con el valor nuevo de las entidades marcadas que cambiaron. Las
+// réplicas aplican los registros confirmados sobre su imagen, y el snapshot
+// es la imagen completa en un índice del log.
+//
+// Los registros solo contienen valores completos de entidades y borrados, así
+// que aplicar un registro dos veces no cambia el resultado.
+type imagenEstado map[string]json.RawMessage
+
+// Registro de cambios: entidades nuevas o modificadas y entidades eliminadas.
+type registroWAL struct {
+	Set imagenEstado `json:",omitempty"`
+	Del []string     `json:",omitempty"`
+}
+
+// Línea del WAL de una réplica: el término y el voto actuales, una entrada
+// nueva del log o el descarte de las entradas desde un índice.
+type registroRaft struct {
+	Estado       *estadoRaft  `json:",omitempty"`
+	Entrada      *entradaRaft `json:",omitempty"`
+	TruncarDesde int64        `json:",omitempty"`
+}
+
+// Estado de Raft que debe sobrevivir a un reinicio, además del log.
+type estadoRaft struct {
+	Term     int64
+	VotedFor string
+}
+
+// Snapshot de una réplica: la imagen con todas las entradas hasta LastIndex
+// aplicadas.
+type snapshotRaft struct {
+	LastIndex int64
+	LastTerm  int64
+	Imagen    imagenEstado
+}
+
+// Almacenamiento local del WAL y los snapshots de una réplica.
+type almacen struct {
+	dir string
+	wal *os.File
+}
+
+// Valores globales del Matchmaker.
+type metaPersistida struct {
+	NextMatchID int32
+	NextPartyID int32
+	VectorClock reloj.Reloj
+	HLC         reloj.Marca
+	StartedAt   time.Time
+	Cancelados  []int32
+}
+
+// Estado de un jugador repartido entre los mapas del servidor.
+type jugadorPersistido struct {
+	Status string      `json:",omitempty"`
+	Mode   string      `json:",omitempty"`
+	Rating *float64    `json:",omitempty"`
+	Match  int32       `json:",omitempty"`
+	Party  int32       `json:",omitempty"`
+	VC     reloj.Reloj `json:",omitempty"`
+}
+
+// Estado de un servidor de juego repartido entre los mapas del servidor.
+type servidorPersistido struct {
+	Info      *GameServerInfo `json:",omitempty"`
+	Match     int32           `json:",omitempty"`
+	Asignadas int32           `json:",omitempty"`
+	Lease     *lease          `json:",omitempty"`
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Abre el directorio de datos de la réplica y recupera el último snapshot, el
+// término y voto actuales y las entradas del log posteriores al snapshot.
+func abrirAlmacen(dir string) (*almacen, *snapshotRaft, estadoRaft, []entradaRaft, error) {
+	var estado estadoRaft
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, estado, nil, err
+	}
+	a := &almacen{dir: dir}
+
+	snap := &snapshotRaft{Imagen: make(imagenEstado)}
+	data, err := os.ReadFile(filepath.Join(dir, snapshotArchivo))
+	if err == nil {
+		if err := json.Unmarshal(data, snap); err != nil {
+			return nil, nil, estado, nil, fmt.Errorf("snapshot corrupto: %v", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, nil, estado, nil, err
+	}
+
+	entradas, estado, valido, err := a.leerWAL(snap.LastIndex)
+	if err != nil {
+		return nil, nil, estado, nil, err
+	}
+
+	// El registro incompleto se corta también del archivo: si no, el próximo
+	// se escribiría pegado a él y el WAL quedaría corrupto en el medio
+	ruta := filepath.Join(dir, walArchivo)
+	if valido >= 0 {
+		if err := os.Truncate(ruta, valido); err != nil {
+			return nil, nil, estado, nil, err
+		}
+	}
+
+	a.wal, err = os.OpenFile(ruta, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, nil, estado, nil, err
+	}
+	return a, snap, estado, entradas, nil
+}
+
+// Reconstruye el log y el estado de Raft a partir del WAL. Las entradas ya
+// incluidas en el snapshot se ignoran. Un último registro incompleto (la
+// réplica cayó mientras lo escribía) se descarta, y se devuelve el tamaño del
+// WAL sin él para cortarlo; si no hay registro incompleto, se devuelve -1.
+func (a *almacen) leerWAL(snapIndex int64) ([]entradaRaft, estadoRaft, int64, error) {
+	var entradas []entradaRaft
+	var estado estadoRaft
+
+	f, err := os.Open(filepath.Join(a.dir, walArchivo))
+	if os.IsNotExist(err) {
+		return nil, estado, -1, nil
+	}
+	if err != nil {
+		return nil, estado, -1, err
+	}
+	defer f.Close()
+
+	// Descarta las entradas desde el índice indicado
+	truncar := func(desde int64) {
+		for len(entradas) > 0 && entradas[len(entradas)-1].Index >= desde {
+			entradas = entradas[:len(entradas)-1]
+		}
+	}
+
+	lector := bufio.NewReaderSize(f, 1024*1024)
+	var pendiente error
+	var valido int64 // bytes del WAL hasta el último registro completo
+	linea := 0
+	for {
+		data, err := lector.ReadBytes('\n')
+		if len(data) == 0 && err == io.EOF {
+			break
+		}
+		if err != nil && err != io.EOF {
+			return nil, estado, -1, err
+		}
+		linea++
+		if pendiente != nil {
+			return nil, estado, -1, pendiente
+		}
+		// Sin el salto de línea final el registro no llegó a escribirse
+		// entero, aunque sea JSON válido
+		if err == io.EOF {
+			pendiente = fmt.Errorf("registro %d del WAL sin terminar", linea)
+			break
+		}
+		var reg registroRaft
+		if err := json.Unmarshal(data, &reg); err != nil {
+			pendiente = fmt.Errorf("registro %d del WAL corrupto: %v", linea, err)
+			continue
+		}
+		valido += int64(len(data))
+		switch {
+		case reg.Estado != nil:
+			estado = *reg.Estado
+		case reg.Entrada != nil:
+			if reg.Entrada.Index <= snapIndex {
+				continue
+			}
+			truncar(reg.Entrada.Index)
+			entradas = append(entradas, *reg.Entrada)
+		case reg.TruncarDesde != 0:
+			truncar(reg.TruncarDesde)
+		}
+	}
+	if pendiente == nil {
+		return entradas, estado, -1, nil
+	}
+	log.Printf("[Matchmaker] Descartado el último registro del WAL, incompleto: %v", pendiente)
+	return entradas, estado, valido, nil
+}
+
+// Agrega registros al WAL y los lleva a disco antes de volver.
+func (a *almacen) agregar(regs ...registroRaft) error {
+	var buf bytes.Buffer
+	for _, reg := range regs {
+		linea, err := json.Marshal(reg)
+		if err != nil {
+			return err
+		}
+		buf.Write(linea)
+		buf.WriteByte('\n')
+	}
+	if _, err := a.wal.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return a.wal.Sync()
+}
+
+// Lleva el WAL a disco y lo cierra.
+func (a *almacen) cerrar() error {
+	if err := a.wal.Sync(); err != nil {
+		a.wal.Close()
+		return err
+	}
+	return a.wal.Close()
+}
+
+// Escribe un snapshot nuevo y reemplaza el WAL por uno con solo el estado de
+// Raft y las entradas posteriores al snapshot. Si la réplica cae entre los dos
+// pasos, al arrancar se ignoran las entradas del WAL que ya están en el
+// snapshot.
+func (a *almacen) compactar(snap *snapshotRaft, estado estadoRaft, entradas []entradaRaft) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	if err := escribirArchivo(filepath.Join(a.dir, snapshotArchivo), data); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	regs := []registroRaft{{Estado: &estado}}
+	for i := range entradas {
+		regs = append(regs, registroRaft{Entrada: &entradas[i]})
+	}
+	for _, reg := range regs {
+		linea, err := json.Marshal(reg)
+		if err != nil {
+			return err
+		}
+		buf.Write(linea)
+		buf.WriteByte('\n')
+	}
+	ruta := filepath.Join(a.dir, walArchivo)
+	if err := escribirArchivo(ruta, buf.Bytes()); err != nil {
+		return err
+	}
+
+	a.wal.Close()
+	a.wal, err = os.OpenFile(ruta, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	return err
+}
+
+// Reemplaza un archivo de forma atómica: escribe una copia temporal, la lleva
+// a disco y la renombra.
+func escribirArchivo(ruta string, data []byte) error {
+	tmp := ruta + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ruta)
+}
+
+// Aplica un registro de cambios sobre la imagen.
+func (img imagenEstado) aplicar(reg registroWAL) {
+	for k, v := range reg.Set {
+		img[k] = v
+	}
+	for _, k := range reg.Del {
+		delete(img, k)
+	}
+}
+
+// Copia la imagen. Los valores no se modifican nunca, así que basta con
+// copiar el mapa.
+func (img imagenEstado) copiar() imagenEstado {
+	c := make(imagenEstado, len(img))
+	for k, v := range img {
+		c[k] = v
+	}
+	return c
+}
+
+// Cambios de la imagen nueva respecto de la anterior. Devuelve false si no
+// hay ninguno.
+func diferencia(anterior, nueva imagenEstado) (registroWAL, bool) {
+	var reg registroWAL
+	for k, v := range nueva {
+		if !bytes.Equal(anterior[k], v) {
+			if reg.Set == nil {
+				reg.Set = make(imagenEstado)
+			}
+			reg.Set[k] = v
+		}
+	}
+	for k := range anterior {
+		if _, ok := nueva[k]; !ok {
+			reg.Del = append(reg.Del, k)
+		}
+	}
+	return reg, reg.Set != nil || reg.Del != nil
+}
+
+// Propone al cluster los cambios hechos en el último paso del bucle de
+// eventos: el valor actual de las entidades que se marcaron como modificadas.
+// Solo el líder propone cambios; las respuestas a los clientes esperan a que
+// el cambio se confirme (ver interceptorLider). Devuelve el índice de la
+// entrada propuesta, o 0 si no hubo nada que proponer.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) proponerCambios() int64 {
+	sucias := s.sucias
+	s.sucias = make(map[string]bool)
+	if !s.sirviendo.Load() {
+		return 0
+	}
+	if comprobarCambios {
+		s.comprobarMarcas(sucias)
+	}
+	if len(sucias) == 0 {
+		return 0
+	}
+
+	// Los relojes del Matchmaker avanzan con cada evento, también en las
+	// consultas, pero no se proponen solos: viajan con el próximo cambio. Si
+	// un líder nuevo queda con un reloj atrasado respecto de lo que ya
+	// respondió el anterior, lo alcanza al recibir el siguiente mensaje
+	sucias["meta"] = true
+
+	var reg registroWAL
+	for clave := range sucias {
+		data, ok := s.valor(clave)
+		if _, antes := s.base[clave]; !ok && antes {
+			reg.Del = append(reg.Del, clave)
+		} else if ok && !bytes.Equal(s.base[clave], data) {
+			if reg.Set == nil {
+				reg.Set = make(imagenEstado)
+			}
+			reg.Set[clave] = data
+		}
+	}
+	if reg.Set == nil && reg.Del == nil {
+		return 0
+	}
+	data, err := json.Marshal(reg)
+	if err != nil {
+		log.Fatalf("[Matchmaker] Error al serializar los cambios: %v", err)
+	}
+	indice, err := s.raft.proponer(data)
+	if err != nil {
+		// Se perdió el liderazgo: el estado se reconstruirá desde el log
+		// confirmado si esta réplica vuelve a ser líder
+		log.Printf("[Matchmaker] Cambios descartados: %v", err)
+		return 0
+	}
+	s.base.aplicar(reg)
+	return indice
+}
+
+// Verifica que todas las entidades que cambiaron respecto de la imagen ya
+// propuesta estén marcadas (salvo meta, que se propone con cualquier otro
+// cambio). Solo se usa en las pruebas (ver comprobarCambios).
+// Debe llamarse desde el bucle de eventos.
+func (s *server) comprobarMarcas(sucias map[string]bool) {
+	reg, _ := diferencia(s.base, s.imagen())
+	for clave := range reg.Set {
+		if clave != "meta" && !sucias[clave] {
+			log.Panicf("[Matchmaker] %s cambió sin marcarse", clave)
+		}
+	}
+	for _, clave := range reg.Del {
+		if !sucias[clave] {
+			log.Panicf("[Matchmaker] %s se eliminó sin marcarse", clave)
+		}
+	}
+}
+
+// Marca una entidad como modificada en este paso del bucle de eventos. Su
+// valor se propone al cluster al terminar el paso.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) marcar(clave string) {
+	s.sucias[clave] = true
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) marcarJugadores(ids ...int32) {
+	for _, id := range ids {
+		s.marcar(fmt.Sprintf("jugador/%d", id))
+	}
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) marcarServidor(id string) {
+	s.marcar("servidor/" + id)
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) marcarPartida(id int32) {
+	s.marcar(fmt.Sprintf("partida/%d", id))
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) marcarReadyCheck(id int32) {
+	s.marcar(fmt.Sprintf("readycheck/%d", id))
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) marcarGrupo(id int32) {
+	s.marcar(fmt.Sprintf("grupo/%d", id))
+}
+
+// Arma la imagen completa del estado actual. Es cara, así que solo se usa
+// para comprobar las marcas.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) imagen() imagenEstado {
+	claves := []string{"meta"}
+	for mode := range s.playersQueue {
+		claves = append(claves, "cola/"+mode)
+	}
+	for mode := range s.throughput {
+		claves = append(claves, "throughput/"+mode)
+	}
+	jugadores := make(map[int32]bool)
+	for _, m := range []map[int32]string{s.playerStatus, s.playerMode} {
+		for id := range m {
+			jugadores[id] = true
+		}
+	}
+	for id := range s.playerRating {
+		jugadores[id] = true
+	}
+	for _, m := range []map[int32]int32{s.playerMatch, s.playerParty} {
+		for id := range m {
+			jugadores[id] = true
+		}
+	}
+	for id := range s.playerVC {
+		jugadores[id] = true
+	}
+	for id := range jugadores {
+		claves = append(claves, fmt.Sprintf("jugador/%d", id))
+	}
+	for id := range s.gameServers {
+		claves = append(claves, "servidor/"+id)
+	}
+	for _, m := range []map[string]int32{s.serverMatch, s.asignadas} {
+		for id := range m {
+			claves = append(claves, "servidor/"+id)
+		}
+	}
+	for id := range s.leases {
+		claves = append(claves, "servidor/"+id)
+	}
+	for id := range s.matches {
+		claves = append(claves, fmt.Sprintf("partida/%d", id))
+	}
+	for id := range s.readyChecks {
+		claves = append(claves, fmt.Sprintf("readycheck/%d", id))
+	}
+	for id := range s.parties {
+		claves = append(claves, fmt.Sprintf("grupo/%d", id))
+	}
+	for clave := range s.respuestas {
+		claves = append(claves, "respuesta/"+clave)
+	}
+	for id := range s.miembros {
+		claves = append(claves, "miembro/"+id)
+	}
+
+	img := make(imagenEstado)
+	for _, clave := range claves {
+		if data, ok := s.valor(clave); ok {
+			img[clave] = data
+		}
+	}
+	return img
+}
+
+// Valor actual de una entidad en JSON, o false si la entidad no existe.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) valor(clave string) (json.RawMessage, bool) {
+	tipo, id, _ := strings.Cut(clave, "/")
+	var v interface{}
+	var ok bool
+	switch tipo {
+	case "meta":
+		v, ok = s.meta(), true
+	case "cola":
+		v, ok = s.playersQueue[id], len(s.playersQueue[id]) > 0
+	case "throughput":
+		v, ok = s.throughput[id]
+	case "jugador":
+		v, ok = s.jugador(id)
+	case "servidor":
+		v, ok = s.servidor(id)
+	case "partida":
+		v, ok = entidad(s.matches, id)
+	case "readycheck":
+		v, ok = entidad(s.readyChecks, id)
+	case "grupo":
+		v, ok = entidad(s.parties, id)
+	case "respuesta":
+		v, ok = s.respuestas[id]
+	case "miembro":
+		v, ok = s.miembros[id]
+	default:
+		log.Fatalf("[Matchmaker] Entidad desconocida: %s", clave)
+	}
+	if !ok {
+		return nil, false
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Fatalf("[Matchmaker] Error al serializar %s: %v", clave, err)
+	}
+	return data, true
+}
+
+// Entidad de un mapa con ID numérico.
+func entidad[T any](m map[int32]*T, id string) (*T, bool) {
+	n, err := parseID(id)
+	if err != nil {
+		return nil, false
+	}
+	v, ok := m[n]
+	return v, ok
+}
+
+// Valores globales del Matchmaker.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) meta() *metaPersistida {
+	meta := &metaPersistida{
+		NextMatchID: s.nextMatchID,
+		NextPartyID: s.nextPartyID,
+		VectorClock: s.vectorClock.Copia(),
+		HLC:         s.hlc.Actual(),
+		StartedAt:   s.startedAt,
+	}
+	for id := range s.cancelados {
+		meta.Cancelados = append(meta.Cancelados, id)
+	}
+	// Orden fijo, para que el valor no cambie si el estado no cambió
+	sort.Slice(meta.Cancelados, func(i, j int) bool { return meta.Cancelados[i] < meta.Cancelados[j] })
+	return meta
+}
+
+// Estado del jugador repartido entre los mapas del servidor, o false si no
+// figura en ninguno.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) jugador(clave string) (*jugadorPersistido, bool) {
+	id, err := parseID(clave)
+	if err != nil {
+		return nil, false
+	}
+	j := &jugadorPersistido{}
+	var ok, hay bool
+	if j.Status, hay = s.playerStatus[id]; hay {
+		ok = true
+	}
+	if j.Mode, hay = s.playerMode[id]; hay {
+		ok = true
+	}
+	if rating, hay := s.playerRating[id]; hay {
+		j.Rating = &rating
+		ok = true
+	}
+	if j.Match, hay = s.playerMatch[id]; hay {
+		ok = true
+	}
+	if j.Party, hay = s.playerParty[id]; hay {
+		ok = true
+	}
+	if j.VC, hay = s.playerVC[id]; hay {
+		ok = true
+	}
+	return j, ok
+}
+
+// Estado del servidor de juego repartido entre los mapas del servidor, o
+// false si no figura en ninguno.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) servidor(id string) (*servidorPersistido, bool) {
+	sv := &servidorPersistido{}
+	var ok, hay bool
+	if sv.Info, hay = s.gameServers[id]; hay {
+		ok = true
+	}
+	if sv.Match, hay = s.serverMatch[id]; hay {
+		ok = true
+	}
+	if sv.Asignadas, hay = s.asignadas[id]; hay {
+		ok = true
+	}
+	if sv.Lease, hay = s.leases[id]; hay {
+		ok = true
+	}
+	return sv, ok
+}
+
+// Reconstruye el estado del servidor a partir de una imagen persistida.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) restaurar(img imagenEstado) error {
+	for clave, data := range img {
+		tipo, id, _ := strings.Cut(clave, "/")
+		var err error
+		switch tipo {
+		case "meta":
+			var meta metaPersistida
+			if err = json.Unmarshal(data, &meta); err == nil {
+				s.nextMatchID = meta.NextMatchID
+				s.nextPartyID = meta.NextPartyID
+				s.vectorClock = meta.VectorClock
+				// La marca HLC del nuevo líder nunca queda antes de las que
+				// ya entregó el anterior
+				s.hlc.Recibir(meta.HLC)
+				s.startedAt = meta.StartedAt
+				for _, p := range meta.Cancelados {
+					s.cancelados[p] = true
+				}
+			}
+		case "cola":
+			var queue []*queueEntry
+			if err = json.Unmarshal(data, &queue); err == nil {
+				s.playersQueue[id] = queue
+			}
+		case "throughput":
+			var muestras []muestraThroughput
+			if err = json.Unmarshal(data, &muestras); err == nil {
+				s.throughput[id] = muestras
+			}
+		case "jugador":
+			var j jugadorPersistido
+			var playerID int32
+			if playerID, err = parseID(id); err == nil {
+				err = json.Unmarshal(data, &j)
+			}
+			if err == nil {
+				s.restaurarJugador(playerID, j)
+			}
+		case "servidor":
+			var sv servidorPersistido
+			if err = json.Unmarshal(data, &sv); err == nil {
+				if sv.Info != nil {
+					s.gameServers[id] = sv.Info
+				}
+				if sv.Match != 0 {
+					s.serverMatch[id] = sv.Match
+				}
+				if sv.Asignadas != 0 {
+					s.asignadas[id] = sv.Asignadas
+				}
+				if sv.Lease != nil {
+					s.leases[id] = sv.Lease
+				}
+			}
+		case "partida":
+			var match Match
+			if err = json.Unmarshal(data, &match); err == nil {
+				s.matches[match.ID] = &match
+			}
+		case "readycheck":
+			var rc readyCheck
+			if err = json.Unmarshal(data, &rc); err == nil {
+				s.readyChecks[rc.MatchID] = &rc
+			}
+		case "grupo":
+			var party Party
+			if err = json.Unmarshal(data, &party); err == nil {
+				s.parties[party.ID] = &party
+			}
+		case "respuesta":
+			var r respuestaGuardada
+			if err = json.Unmarshal(data, &r); err == nil {
+				s.respuestas[id] = &r
+			}
+		case "miembro":
+			var m miembro
+			if err = json.Unmarshal(data, &m); err == nil {
+				s.miembros[id] = &m
+			}
+		default:
+			err = fmt.Errorf("tipo de entidad desconocido")
+		}
+		if err != nil {
+			return fmt.Errorf("entidad %s: %v", clave, err)
+		}
+	}
+	return nil
+}
+
+// Debe llamarse desde el bucle de eventos.
+func (s *server) restaurarJugador(id int32, j jugadorPersistido) {
+	if j.Status != "" {
+		s.playerStatus[id] = j.Status
+	}
+	if j.Mode != "" {
+		s.playerMode[id] = j.Mode
+	}
+	if j.Rating != nil {
+		s.playerRating[id] = *j.Rating
+	}
+	if j.Match != 0 {
+		s.playerMatch[id] = j.Match
+	}
+	if j.Party != 0 {
+		s.playerParty[id] = j.Party
+	}
+	if j.VC != nil {
+		s.playerVC[id] = j.VC
+	}
+}
+
+// Retoma el trabajo que quedó pendiente al cambiar de líder (o al reiniciar
+// el Matchmaker): vuelve a programar el vencimiento de los ready-checks. Las
+// partidas en curso siguen así hasta que su servidor informe el resultado, y
+// las que el servidor no llegó a confirmar se le vuelven a enviar.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) reanudar() {
+	for _, rc := range s.readyChecks {
+		matchID := rc.MatchID
+		time.AfterFunc(time.Until(rc.Deadline), func() { s.ejecutar(func() { s.expirarReadyCheck(matchID) }) })
+	}
+
+	for _, match := range s.matches {
+		if match.Status != "EN CURSO" || match.Recibida {
+			continue
+		}
+		gs, ok := s.gameServers[match.ServerID]
+		if !ok {
+			log.Printf("[Matchmaker] Partida %d cancelada: %s se dio de baja sin confirmar haberla recibido", match.ID, match.ServerID)
+			s.cerrarPartida(match, "CANCELADA")
+			s.liberarJugadores(match)
+			continue
+		}
+		// El servidor pudo haberla recibido sin que la confirmación llegara a
+		// replicarse. Se reenvía con la misma clave de idempotencia (ver
+		// contextoAsignacion): si ya la tenía, no la inicia de nuevo
+		log.Printf("[Matchmaker] Reenviando la partida %d a %s: no confirmó haberla recibido antes del cambio de líder", match.ID, gs.ID)
+		s.enviarAssignMatch(gs, match, s.entradasDePartida(match), 0)
+	}
+
+	s.despertarMatchmaking()
+}
+
+func parseID(s string) (int32, error) {
+	id, err := strconv.ParseInt(s, 10, 32)
+	return int32(id), err
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "MV4/proto/grpc-server/proto"
+)
+
+// Las consultas no agregan entradas al log ni esperan confirmaciones; los
+// cambios, sí.
+func TestConsultasNoProponenCambios(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	for id := range c.peers {
+		c.arrancar(id)
+	}
+	liderID, _ := c.esperarLider()
+	lider := c.replicas[liderID].srv.raft
+	cli := c.cliente(liderID)
+	ctx := contexto(t)
+
+	if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: 1}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	antes := lider.ultimaEntrada()
+
+	for i := 0; i < 20; i++ {
+		if _, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: 1}); err != nil {
+			t.Fatalf("GetPlayerStatus: %v", err)
+		}
+		if _, err := cli.EstimateWaitTime(ctx, &pb.WaitTimeRequest{PlayerId: 1}); err != nil {
+			t.Fatalf("EstimateWaitTime: %v", err)
+		}
+		if _, err := cli.AdminGetSystemStatus(ctx, &pb.AdminRequest{AdminId: "prueba"}); err != nil {
+			t.Fatalf("AdminGetSystemStatus: %v", err)
+		}
+	}
+	if despues := lider.ultimaEntrada(); despues != antes {
+		t.Errorf("Las consultas agregaron %d entradas al log", despues-antes)
+	}
+
+	if _, err := cli.LeaveQueue(ctx, &pb.LeaveQueueRequest{PlayerId: 1}); err != nil {
+		t.Fatalf("LeaveQueue: %v", err)
+	}
+	if lider.ultimaEntrada() == antes {
+		t.Error("LeaveQueue no agregó una entrada al log")
+	}
+}
+
+// Un registro que quedó a medio escribir al caer la réplica se corta del WAL
+// al reabrirlo, así los registros siguientes no quedan pegados a él y el WAL
+// se puede volver a leer después.
+func TestWALCortadoSeRecuperaYSigueCreciendo(t *testing.T) {
+	dir := t.TempDir()
+	escribir := func(desde, hasta int64) {
+		t.Helper()
+		alm, _, _, _, err := abrirAlmacen(dir)
+		if err != nil {
+			t.Fatalf("Error al abrir el almacén: %v", err)
+		}
+		for i := desde; i <= hasta; i++ {
+			if err := alm.agregar(registroRaft{Entrada: &entradaRaft{Index: i, Term: 1}}); err != nil {
+				t.Fatalf("Error al agregar la entrada %d: %v", i, err)
+			}
+		}
+		if err := alm.cerrar(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	comprobar := func(esperadas int64) {
+		t.Helper()
+		alm, _, _, entradas, err := abrirAlmacen(dir)
+		if err != nil {
+			t.Fatalf("Error al reabrir el almacén: %v", err)
+		}
+		alm.cerrar()
+		if int64(len(entradas)) != esperadas {
+			t.Fatalf("Se recuperaron %d entradas, se esperaban %d", len(entradas), esperadas)
+		}
+		for i, e := range entradas {
+			if e.Index != int64(i+1) {
+				t.Fatalf("La entrada %d tiene el índice %d", i+1, e.Index)
+			}
+		}
+	}
+
+	escribir(1, 3)
+	wal, err := os.OpenFile(filepath.Join(dir, walArchivo), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wal.WriteString(`{"Entrada":{"Index":4,"Te`)
+	wal.Close()
+
+	comprobar(3)
+	escribir(4, 5)
+	comprobar(5)
+}
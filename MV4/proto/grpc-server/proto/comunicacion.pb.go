@@ -134,28 +134,29 @@ func (x *QueuePlayerResponse) GetVectorClock() *VectorClock {
 	return nil
 }
 
-// Mensajes para la funcionalidad de consulta del estado del jugador
-type PlayerStatusRequest struct {
+// Mensajes para la funcionalidad de salida de la cola
+type LeaveQueueRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // ID del jugador para consultar su estado
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`         // ID del jugador que cancela la búsqueda
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerStatusRequest) Reset() {
-	*x = PlayerStatusRequest{}
+func (x *LeaveQueueRequest) Reset() {
+	*x = LeaveQueueRequest{}
 	mi := &file_comunicacion_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerStatusRequest) String() string {
+func (x *LeaveQueueRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerStatusRequest) ProtoMessage() {}
+func (*LeaveQueueRequest) ProtoMessage() {}
 
-func (x *PlayerStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *LeaveQueueRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -167,42 +168,48 @@ func (x *PlayerStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerStatusRequest.ProtoReflect.Descriptor instead.
-func (*PlayerStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaveQueueRequest.ProtoReflect.Descriptor instead.
+func (*LeaveQueueRequest) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *PlayerStatusRequest) GetPlayerId() int32 {
+func (x *LeaveQueueRequest) GetPlayerId() int32 {
 	if x != nil {
 		return x.PlayerId
 	}
 	return 0
 }
 
-type PlayerStatusResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Status             string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                                     // Estado del jugador, por ejemplo, "IDLE", "IN QUEUE", "IN MATCH"
-	MatchId            int32                  `protobuf:"varint,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                                   // ID de la partida, si está en una
-	MatchServerAddress string                 `protobuf:"bytes,5,opt,name=match_server_address,json=matchServerAddress,proto3" json:"match_server_address,omitempty"` // Dirección del servidor de la partida, si está en una
-	VectorClock        *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                        // Vector de reloj para la sincronización
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *LeaveQueueRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
 }
 
-func (x *PlayerStatusResponse) Reset() {
-	*x = PlayerStatusResponse{}
+type LeaveQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // Código de estado, por ejemplo, "SUCCESS", "FAILURE"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // Mensaje adicional
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveQueueResponse) Reset() {
+	*x = LeaveQueueResponse{}
 	mi := &file_comunicacion_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerStatusResponse) String() string {
+func (x *LeaveQueueResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerStatusResponse) ProtoMessage() {}
+func (*LeaveQueueResponse) ProtoMessage() {}
 
-func (x *PlayerStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *LeaveQueueResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -214,63 +221,57 @@ func (x *PlayerStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerStatusResponse.ProtoReflect.Descriptor instead.
-func (*PlayerStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaveQueueResponse.ProtoReflect.Descriptor instead.
+func (*LeaveQueueResponse) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PlayerStatusResponse) GetStatus() string {
+func (x *LeaveQueueResponse) GetStatusCode() string {
 	if x != nil {
-		return x.Status
+		return x.StatusCode
 	}
 	return ""
 }
 
-func (x *PlayerStatusResponse) GetMatchId() int32 {
-	if x != nil {
-		return x.MatchId
-	}
-	return 0
-}
-
-func (x *PlayerStatusResponse) GetMatchServerAddress() string {
+func (x *LeaveQueueResponse) GetMessage() string {
 	if x != nil {
-		return x.MatchServerAddress
+		return x.Message
 	}
 	return ""
 }
 
-func (x *PlayerStatusResponse) GetVectorClock() *VectorClock {
+func (x *LeaveQueueResponse) GetVectorClock() *VectorClock {
 	if x != nil {
 		return x.VectorClock
 	}
 	return nil
 }
 
-// Mensajes para la funcionalidad de asignación de partidas
-type AssignMatchRequest struct {
+// Mensajes para la funcionalidad de ready-check (aceptar/rechazar partida)
+type ReadyCheckRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MatchId       int32                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                 // ID único de la partida asignada
-	PlayersIds    []int32                `protobuf:"varint,2,rep,packed,name=players_ids,json=playersIds,proto3" json:"players_ids,omitempty"` // IDs de los jugadores asignados a la partida
-	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`      // Vector de reloj para la sincronización
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`         // ID del jugador que responde
+	MatchId       int32                  `protobuf:"varint,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`            // ID de la partida encontrada
+	Accept        bool                   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`                             // true para aceptar, false para rechazar
+	VectorClock   *VectorClock           `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignMatchRequest) Reset() {
-	*x = AssignMatchRequest{}
+func (x *ReadyCheckRequest) Reset() {
+	*x = ReadyCheckRequest{}
 	mi := &file_comunicacion_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignMatchRequest) String() string {
+func (x *ReadyCheckRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignMatchRequest) ProtoMessage() {}
+func (*ReadyCheckRequest) ProtoMessage() {}
 
-func (x *AssignMatchRequest) ProtoReflect() protoreflect.Message {
+func (x *ReadyCheckRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -282,57 +283,62 @@ func (x *AssignMatchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignMatchRequest.ProtoReflect.Descriptor instead.
-func (*AssignMatchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadyCheckRequest.ProtoReflect.Descriptor instead.
+func (*ReadyCheckRequest) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *AssignMatchRequest) GetMatchId() int32 {
+func (x *ReadyCheckRequest) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *ReadyCheckRequest) GetMatchId() int32 {
 	if x != nil {
 		return x.MatchId
 	}
 	return 0
 }
 
-func (x *AssignMatchRequest) GetPlayersIds() []int32 {
+func (x *ReadyCheckRequest) GetAccept() bool {
 	if x != nil {
-		return x.PlayersIds
+		return x.Accept
 	}
-	return nil
+	return false
 }
 
-func (x *AssignMatchRequest) GetVectorClock() *VectorClock {
+func (x *ReadyCheckRequest) GetVectorClock() *VectorClock {
 	if x != nil {
 		return x.VectorClock
 	}
 	return nil
 }
 
-type AssignMatchResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Message            string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                                   // Mensaje adicional, por ejemplo, si la partida fue asignada correctamente
-	MatchId            int32                  `protobuf:"varint,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                                   // ID de la partida asignada
-	PlayersIds         []int32                `protobuf:"varint,3,rep,packed,name=players_ids,json=playersIds,proto3" json:"players_ids,omitempty"`                   // IDs de los jugadores asignados a la partida
-	MatchServerAddress string                 `protobuf:"bytes,4,opt,name=match_server_address,json=matchServerAddress,proto3" json:"match_server_address,omitempty"` // Dirección del servidor de la partida asignada
-	VectorClock        *VectorClock           `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                        // Vector de reloj para la sincronización
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type ReadyCheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // Código de estado, por ejemplo, "SUCCESS", "FAILURE"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // Mensaje adicional
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignMatchResponse) Reset() {
-	*x = AssignMatchResponse{}
+func (x *ReadyCheckResponse) Reset() {
+	*x = ReadyCheckResponse{}
 	mi := &file_comunicacion_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignMatchResponse) String() string {
+func (x *ReadyCheckResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignMatchResponse) ProtoMessage() {}
+func (*ReadyCheckResponse) ProtoMessage() {}
 
-func (x *AssignMatchResponse) ProtoReflect() protoreflect.Message {
+func (x *ReadyCheckResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,71 +350,56 @@ func (x *AssignMatchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignMatchResponse.ProtoReflect.Descriptor instead.
-func (*AssignMatchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReadyCheckResponse.ProtoReflect.Descriptor instead.
+func (*ReadyCheckResponse) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *AssignMatchResponse) GetMessage() string {
+func (x *ReadyCheckResponse) GetStatusCode() string {
 	if x != nil {
-		return x.Message
+		return x.StatusCode
 	}
 	return ""
 }
 
-func (x *AssignMatchResponse) GetMatchId() int32 {
-	if x != nil {
-		return x.MatchId
-	}
-	return 0
-}
-
-func (x *AssignMatchResponse) GetPlayersIds() []int32 {
-	if x != nil {
-		return x.PlayersIds
-	}
-	return nil
-}
-
-func (x *AssignMatchResponse) GetMatchServerAddress() string {
+func (x *ReadyCheckResponse) GetMessage() string {
 	if x != nil {
-		return x.MatchServerAddress
+		return x.Message
 	}
 	return ""
 }
 
-func (x *AssignMatchResponse) GetVectorClock() *VectorClock {
+func (x *ReadyCheckResponse) GetVectorClock() *VectorClock {
 	if x != nil {
 		return x.VectorClock
 	}
 	return nil
 }
 
-// Mensajes para la funcionalidad de actualización del estado del servidor
-type ServerStatusUpdateRequest struct {
+// Mensajes para la funcionalidad de estimación del tiempo de espera
+type WaitTimeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`          // Dirección del servidor que está enviando la actualización
-	NewStatus     string                 `protobuf:"bytes,2,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`       // Estado del servidor, por ejemplo, "ONLINE", "OFFLINE", "MAINTENANCE"
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`                            // Dirección del servidor, por ejemplo
-	VectorClock   *VectorClock           `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`         // ID del jugador en cola
+	GameMode      string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`          // Modo de juego a consultar si el jugador no está en cola
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServerStatusUpdateRequest) Reset() {
-	*x = ServerStatusUpdateRequest{}
+func (x *WaitTimeRequest) Reset() {
+	*x = WaitTimeRequest{}
 	mi := &file_comunicacion_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerStatusUpdateRequest) String() string {
+func (x *WaitTimeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerStatusUpdateRequest) ProtoMessage() {}
+func (*WaitTimeRequest) ProtoMessage() {}
 
-func (x *ServerStatusUpdateRequest) ProtoReflect() protoreflect.Message {
+func (x *WaitTimeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -420,61 +411,58 @@ func (x *ServerStatusUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerStatusUpdateRequest.ProtoReflect.Descriptor instead.
-func (*ServerStatusUpdateRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WaitTimeRequest.ProtoReflect.Descriptor instead.
+func (*WaitTimeRequest) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ServerStatusUpdateRequest) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
-}
-
-func (x *ServerStatusUpdateRequest) GetNewStatus() string {
+func (x *WaitTimeRequest) GetPlayerId() int32 {
 	if x != nil {
-		return x.NewStatus
+		return x.PlayerId
 	}
-	return ""
+	return 0
 }
 
-func (x *ServerStatusUpdateRequest) GetAddress() string {
+func (x *WaitTimeRequest) GetGameMode() string {
 	if x != nil {
-		return x.Address
+		return x.GameMode
 	}
 	return ""
 }
 
-func (x *ServerStatusUpdateRequest) GetVectorClock() *VectorClock {
+func (x *WaitTimeRequest) GetVectorClock() *VectorClock {
 	if x != nil {
 		return x.VectorClock
 	}
 	return nil
 }
 
-type ServerStatusUpdateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // Código de estado de la actualización, por ejemplo, "SUCCESS", "FAILURE"
-	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type WaitTimeResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode           string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`                                  // "SUCCESS", o "UNKNOWN" si aún no hay partidas recientes para estimar
+	GameMode             string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`                                        // Modo de juego de la estimación
+	TimeInQueue          string                 `protobuf:"bytes,3,opt,name=time_in_queue,json=timeInQueue,proto3" json:"time_in_queue,omitempty"`                             // Tiempo que el jugador lleva en cola
+	EstimatedWaitSeconds int32                  `protobuf:"varint,4,opt,name=estimated_wait_seconds,json=estimatedWaitSeconds,proto3" json:"estimated_wait_seconds,omitempty"` // Espera restante estimada en segundos (-1 si se desconoce)
+	PlayersAhead         int32                  `protobuf:"varint,5,opt,name=players_ahead,json=playersAhead,proto3" json:"players_ahead,omitempty"`                           // Jugadores por delante en la cola
+	VectorClock          *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                               // Vector de reloj para la sincronización
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
-func (x *ServerStatusUpdateResponse) Reset() {
-	*x = ServerStatusUpdateResponse{}
+func (x *WaitTimeResponse) Reset() {
+	*x = WaitTimeResponse{}
 	mi := &file_comunicacion_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerStatusUpdateResponse) String() string {
+func (x *WaitTimeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerStatusUpdateResponse) ProtoMessage() {}
+func (*WaitTimeResponse) ProtoMessage() {}
 
-func (x *ServerStatusUpdateResponse) ProtoReflect() protoreflect.Message {
+func (x *WaitTimeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -486,47 +474,76 @@ func (x *ServerStatusUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerStatusUpdateResponse.ProtoReflect.Descriptor instead.
-func (*ServerStatusUpdateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WaitTimeResponse.ProtoReflect.Descriptor instead.
+func (*WaitTimeResponse) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ServerStatusUpdateResponse) GetStatusCode() string {
+func (x *WaitTimeResponse) GetStatusCode() string {
 	if x != nil {
 		return x.StatusCode
 	}
 	return ""
 }
 
-func (x *ServerStatusUpdateResponse) GetVectorClock() *VectorClock {
+func (x *WaitTimeResponse) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *WaitTimeResponse) GetTimeInQueue() string {
+	if x != nil {
+		return x.TimeInQueue
+	}
+	return ""
+}
+
+func (x *WaitTimeResponse) GetEstimatedWaitSeconds() int32 {
+	if x != nil {
+		return x.EstimatedWaitSeconds
+	}
+	return 0
+}
+
+func (x *WaitTimeResponse) GetPlayersAhead() int32 {
+	if x != nil {
+		return x.PlayersAhead
+	}
+	return 0
+}
+
+func (x *WaitTimeResponse) GetVectorClock() *VectorClock {
 	if x != nil {
 		return x.VectorClock
 	}
 	return nil
 }
 
-// Mensajes para la funcionalidad del Cliente Administrador
-type AdminRequest struct {
+// Mensajes para la funcionalidad de consulta del estado del jugador
+type PlayerStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AdminId       string                 `protobuf:"bytes,1,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"` // ID del administrador que solicita el estado del sistema
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`         // ID del jugador para consultar su estado
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminRequest) Reset() {
-	*x = AdminRequest{}
+func (x *PlayerStatusRequest) Reset() {
+	*x = PlayerStatusRequest{}
 	mi := &file_comunicacion_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminRequest) String() string {
+func (x *PlayerStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminRequest) ProtoMessage() {}
+func (*PlayerStatusRequest) ProtoMessage() {}
 
-func (x *AdminRequest) ProtoReflect() protoreflect.Message {
+func (x *PlayerStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -538,42 +555,53 @@ func (x *AdminRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminRequest.ProtoReflect.Descriptor instead.
-func (*AdminRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlayerStatusRequest.ProtoReflect.Descriptor instead.
+func (*PlayerStatusRequest) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *AdminRequest) GetAdminId() string {
+func (x *PlayerStatusRequest) GetPlayerId() int32 {
 	if x != nil {
-		return x.AdminId
+		return x.PlayerId
 	}
-	return ""
+	return 0
 }
 
-type ServerState struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                  // ID o dirección del servidor
-	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                          // Estado del servidor, por ejemplo, "ONLINE", "OFFLINE"
-	Address        string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`                                        // Dirección del servidor
-	CurrentMatchId int32                  `protobuf:"varint,4,opt,name=current_match_id,json=currentMatchId,proto3" json:"current_match_id,omitempty"` // ID de la partida actual, si aplica
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *PlayerStatusRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
 }
 
-func (x *ServerState) Reset() {
-	*x = ServerState{}
+type PlayerStatusResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Status                string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                                                 // Estado del jugador, por ejemplo, "IDLE", "IN QUEUE", "IN MATCH"
+	MatchId               int32                  `protobuf:"varint,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                                               // ID de la partida, si está en una
+	MatchServerAddress    string                 `protobuf:"bytes,5,opt,name=match_server_address,json=matchServerAddress,proto3" json:"match_server_address,omitempty"`             // Dirección del servidor de la partida, si está en una
+	VectorClock           *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                                    // Vector de reloj para la sincronización
+	Rating                int32                  `protobuf:"varint,7,opt,name=rating,proto3" json:"rating,omitempty"`                                                                // Rating de habilidad (Elo) actual del jugador
+	PartyId               int32                  `protobuf:"varint,8,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`                                               // ID del grupo del jugador (0 si no está en un grupo)
+	ReadyCheckSecondsLeft int32                  `protobuf:"varint,9,opt,name=ready_check_seconds_left,json=readyCheckSecondsLeft,proto3" json:"ready_check_seconds_left,omitempty"` // Segundos restantes para aceptar la partida, si está en "MATCH FOUND"
+	Match                 *MatchInfo             `protobuf:"bytes,10,opt,name=match,proto3" json:"match,omitempty"`                                                                  // Registro de la partida actual del jugador, si está en una
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *PlayerStatusResponse) Reset() {
+	*x = PlayerStatusResponse{}
 	mi := &file_comunicacion_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerState) String() string {
+func (x *PlayerStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerState) ProtoMessage() {}
+func (*PlayerStatusResponse) ProtoMessage() {}
 
-func (x *ServerState) ProtoReflect() protoreflect.Message {
+func (x *PlayerStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -585,61 +613,91 @@ func (x *ServerState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerState.ProtoReflect.Descriptor instead.
-func (*ServerState) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlayerStatusResponse.ProtoReflect.Descriptor instead.
+func (*PlayerStatusResponse) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ServerState) GetId() string {
+func (x *PlayerStatusResponse) GetStatus() string {
 	if x != nil {
-		return x.Id
+		return x.Status
 	}
 	return ""
 }
 
-func (x *ServerState) GetStatus() string {
+func (x *PlayerStatusResponse) GetMatchId() int32 {
 	if x != nil {
-		return x.Status
+		return x.MatchId
 	}
-	return ""
+	return 0
 }
 
-func (x *ServerState) GetAddress() string {
+func (x *PlayerStatusResponse) GetMatchServerAddress() string {
 	if x != nil {
-		return x.Address
+		return x.MatchServerAddress
 	}
 	return ""
 }
 
-func (x *ServerState) GetCurrentMatchId() int32 {
+func (x *PlayerStatusResponse) GetVectorClock() *VectorClock {
 	if x != nil {
-		return x.CurrentMatchId
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *PlayerStatusResponse) GetRating() int32 {
+	if x != nil {
+		return x.Rating
 	}
 	return 0
 }
 
-type PlayerQueueEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // ID del jugador en la cola
-	TimeInQueue   string                 `protobuf:"bytes,2,opt,name=time_in_queue,json=timeInQueue,proto3" json:"time_in_queue,omitempty"` // Tiempo en cola (puede ser string o int64 para segundos)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PlayerStatusResponse) GetPartyId() int32 {
+	if x != nil {
+		return x.PartyId
+	}
+	return 0
 }
 
-func (x *PlayerQueueEntry) Reset() {
-	*x = PlayerQueueEntry{}
-	mi := &file_comunicacion_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+func (x *PlayerStatusResponse) GetReadyCheckSecondsLeft() int32 {
+	if x != nil {
+		return x.ReadyCheckSecondsLeft
+	}
+	return 0
+}
+
+func (x *PlayerStatusResponse) GetMatch() *MatchInfo {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+// Mensajes para la funcionalidad de grupos (parties)
+type PartyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`         // ID del jugador que realiza la operación
+	PartyId       int32                  `protobuf:"varint,2,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`            // ID del grupo (solo para JoinParty)
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartyRequest) Reset() {
+	*x = PartyRequest{}
+	mi := &file_comunicacion_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerQueueEntry) String() string {
+func (x *PartyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerQueueEntry) ProtoMessage() {}
+func (*PartyRequest) ProtoMessage() {}
 
-func (x *PlayerQueueEntry) ProtoReflect() protoreflect.Message {
+func (x *PartyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_comunicacion_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -651,50 +709,1801 @@ func (x *PlayerQueueEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerQueueEntry.ProtoReflect.Descriptor instead.
-func (*PlayerQueueEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use PartyRequest.ProtoReflect.Descriptor instead.
+func (*PartyRequest) Descriptor() ([]byte, []int) {
 	return file_comunicacion_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PlayerQueueEntry) GetPlayerId() int32 {
+func (x *PartyRequest) GetPlayerId() int32 {
 	if x != nil {
 		return x.PlayerId
 	}
 	return 0
 }
 
-func (x *PlayerQueueEntry) GetTimeInQueue() string {
+func (x *PartyRequest) GetPartyId() int32 {
+	if x != nil {
+		return x.PartyId
+	}
+	return 0
+}
+
+func (x *PartyRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type PartyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`         // Código de estado, por ejemplo, "SUCCESS", "FAILURE"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                 // Mensaje adicional
+	PartyId       int32                  `protobuf:"varint,3,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`                 // ID del grupo
+	LeaderId      int32                  `protobuf:"varint,4,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`              // ID del líder del grupo
+	MembersIds    []int32                `protobuf:"varint,5,rep,packed,name=members_ids,json=membersIds,proto3" json:"members_ids,omitempty"` // IDs de los miembros del grupo
+	VectorClock   *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`      // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartyResponse) Reset() {
+	*x = PartyResponse{}
+	mi := &file_comunicacion_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartyResponse) ProtoMessage() {}
+
+func (x *PartyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartyResponse.ProtoReflect.Descriptor instead.
+func (*PartyResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PartyResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *PartyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PartyResponse) GetPartyId() int32 {
+	if x != nil {
+		return x.PartyId
+	}
+	return 0
+}
+
+func (x *PartyResponse) GetLeaderId() int32 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *PartyResponse) GetMembersIds() []int32 {
+	if x != nil {
+		return x.MembersIds
+	}
+	return nil
+}
+
+func (x *PartyResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Mensajes para la funcionalidad de asignación de partidas
+type AssignMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       int32                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`            // ID único de la partida asignada
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	GameMode      string                 `protobuf:"bytes,4,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`          // Modo de juego de la partida
+	Teams         []*Team                `protobuf:"bytes,5,rep,name=teams,proto3" json:"teams,omitempty"`                                // Equipos de la partida (en free-for-all, un equipo por jugador)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignMatchRequest) Reset() {
+	*x = AssignMatchRequest{}
+	mi := &file_comunicacion_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignMatchRequest) ProtoMessage() {}
+
+func (x *AssignMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignMatchRequest.ProtoReflect.Descriptor instead.
+func (*AssignMatchRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssignMatchRequest) GetMatchId() int32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *AssignMatchRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *AssignMatchRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *AssignMatchRequest) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+// Equipo dentro de una partida
+type Team struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamId        int32                  `protobuf:"varint,1,opt,name=team_id,json=teamId,proto3" json:"team_id,omitempty"`                    // ID del equipo dentro de la partida (1, 2, ...)
+	PlayersIds    []int32                `protobuf:"varint,2,rep,packed,name=players_ids,json=playersIds,proto3" json:"players_ids,omitempty"` // IDs de los jugadores del equipo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_comunicacion_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Team) GetTeamId() int32 {
+	if x != nil {
+		return x.TeamId
+	}
+	return 0
+}
+
+func (x *Team) GetPlayersIds() []int32 {
+	if x != nil {
+		return x.PlayersIds
+	}
+	return nil
+}
+
+// Registro de una partida en el matchmaker
+type MatchInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       int32                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                  // ID de la partida
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                // ID del servidor de juego asignado
+	ServerAddress string                 `protobuf:"bytes,3,opt,name=server_address,json=serverAddress,proto3" json:"server_address,omitempty"` // Dirección del servidor de juego asignado
+	GameMode      string                 `protobuf:"bytes,4,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`                // Modo de juego de la partida
+	Teams         []*Team                `protobuf:"bytes,5,rep,name=teams,proto3" json:"teams,omitempty"`                                      // Equipos de la partida
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                    // Estado de la partida: "MATCH FOUND", "EN CURSO", "FINALIZADA" o "CANCELADA"
+	StartTime     string                 `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`             // Inicio de la partida en formato RFC 3339 (vacío si aún no comienza)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchInfo) Reset() {
+	*x = MatchInfo{}
+	mi := &file_comunicacion_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchInfo) ProtoMessage() {}
+
+func (x *MatchInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchInfo.ProtoReflect.Descriptor instead.
+func (*MatchInfo) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MatchInfo) GetMatchId() int32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *MatchInfo) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MatchInfo) GetServerAddress() string {
+	if x != nil {
+		return x.ServerAddress
+	}
+	return ""
+}
+
+func (x *MatchInfo) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *MatchInfo) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *MatchInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MatchInfo) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+type AssignMatchResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Message            string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`                                                   // Mensaje adicional, por ejemplo, si la partida fue asignada correctamente
+	MatchId            int32                  `protobuf:"varint,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                                   // ID de la partida asignada
+	PlayersIds         []int32                `protobuf:"varint,3,rep,packed,name=players_ids,json=playersIds,proto3" json:"players_ids,omitempty"`                   // IDs de los jugadores asignados a la partida
+	MatchServerAddress string                 `protobuf:"bytes,4,opt,name=match_server_address,json=matchServerAddress,proto3" json:"match_server_address,omitempty"` // Dirección del servidor de la partida asignada
+	VectorClock        *VectorClock           `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                        // Vector de reloj para la sincronización
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AssignMatchResponse) Reset() {
+	*x = AssignMatchResponse{}
+	mi := &file_comunicacion_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignMatchResponse) ProtoMessage() {}
+
+func (x *AssignMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignMatchResponse.ProtoReflect.Descriptor instead.
+func (*AssignMatchResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AssignMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssignMatchResponse) GetMatchId() int32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *AssignMatchResponse) GetPlayersIds() []int32 {
+	if x != nil {
+		return x.PlayersIds
+	}
+	return nil
+}
+
+func (x *AssignMatchResponse) GetMatchServerAddress() string {
+	if x != nil {
+		return x.MatchServerAddress
+	}
+	return ""
+}
+
+func (x *AssignMatchResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Mensajes para la funcionalidad de reporte del resultado de una partida
+type MatchResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       int32                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`                     // ID de la partida terminada
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                   // ID del servidor que jugó la partida
+	WinningTeamId int32                  `protobuf:"varint,3,opt,name=winning_team_id,json=winningTeamId,proto3" json:"winning_team_id,omitempty"` // ID del equipo ganador (0 si no hubo ganador)
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`            // Duración de la partida en milisegundos
+	VectorClock   *VectorClock           `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`          // Reloj del servidor al terminar la partida
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResultRequest) Reset() {
+	*x = MatchResultRequest{}
+	mi := &file_comunicacion_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResultRequest) ProtoMessage() {}
+
+func (x *MatchResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResultRequest.ProtoReflect.Descriptor instead.
+func (*MatchResultRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MatchResultRequest) GetMatchId() int32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *MatchResultRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MatchResultRequest) GetWinningTeamId() int32 {
+	if x != nil {
+		return x.WinningTeamId
+	}
+	return 0
+}
+
+func (x *MatchResultRequest) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MatchResultRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type MatchResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // "SUCCESS" si se registró el resultado, "FAILURE" si la partida no estaba en curso en ese servidor
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // Mensaje adicional
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResultResponse) Reset() {
+	*x = MatchResultResponse{}
+	mi := &file_comunicacion_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResultResponse) ProtoMessage() {}
+
+func (x *MatchResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResultResponse.ProtoReflect.Descriptor instead.
+func (*MatchResultResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MatchResultResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *MatchResultResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MatchResultResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Mensajes para la funcionalidad de actualización del estado del servidor
+type ServerStatusUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`          // Dirección del servidor que está enviando la actualización
+	NewStatus     string                 `protobuf:"bytes,2,opt,name=new_status,json=newStatus,proto3" json:"new_status,omitempty"`       // Estado del servidor, por ejemplo, "ONLINE", "OFFLINE", "MAINTENANCE"
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`                            // Dirección del servidor, por ejemplo
+	VectorClock   *VectorClock           `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	Capacity      int32                  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`                         // Capacidad relativa del servidor (peso en la selección ponderada)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatusUpdateRequest) Reset() {
+	*x = ServerStatusUpdateRequest{}
+	mi := &file_comunicacion_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatusUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatusUpdateRequest) ProtoMessage() {}
+
+func (x *ServerStatusUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatusUpdateRequest.ProtoReflect.Descriptor instead.
+func (*ServerStatusUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServerStatusUpdateRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetNewStatus() string {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *ServerStatusUpdateRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type ServerStatusUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // Código de estado de la actualización, por ejemplo, "SUCCESS", "FAILURE"
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatusUpdateResponse) Reset() {
+	*x = ServerStatusUpdateResponse{}
+	mi := &file_comunicacion_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatusUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatusUpdateResponse) ProtoMessage() {}
+
+func (x *ServerStatusUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatusUpdateResponse.ProtoReflect.Descriptor instead.
+func (*ServerStatusUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ServerStatusUpdateResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Mensajes para la funcionalidad del Cliente Administrador
+type AdminRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminId       string                 `protobuf:"bytes,1,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"` // ID del administrador que solicita el estado del sistema
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminRequest) Reset() {
+	*x = AdminRequest{}
+	mi := &file_comunicacion_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminRequest) ProtoMessage() {}
+
+func (x *AdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminRequest.ProtoReflect.Descriptor instead.
+func (*AdminRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AdminRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+type ServerState struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                   // ID o dirección del servidor
+	Status          string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                           // Estado del servidor, por ejemplo, "ONLINE", "OFFLINE"
+	Address         string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`                                         // Dirección del servidor
+	CurrentMatchId  int32                  `protobuf:"varint,4,opt,name=current_match_id,json=currentMatchId,proto3" json:"current_match_id,omitempty"`  // ID de la partida actual, si aplica
+	Capacity        int32                  `protobuf:"varint,5,opt,name=capacity,proto3" json:"capacity,omitempty"`                                      // Capacidad relativa informada por el servidor
+	MatchesAssigned int32                  `protobuf:"varint,6,opt,name=matches_assigned,json=matchesAssigned,proto3" json:"matches_assigned,omitempty"` // Partidas asignadas al servidor desde que arrancó el Matchmaker
+	LastUpdate      string                 `protobuf:"bytes,7,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`                 // Última vez que el servidor informó su estado o respondió un ping (RFC3339)
+	LeaseExpired    bool                   `protobuf:"varint,8,opt,name=lease_expired,json=leaseExpired,proto3" json:"lease_expired,omitempty"`          // true si el servidor dejó de renovar su lease y ya no recibe partidas
+	LeaseExpiresAt  string                 `protobuf:"bytes,9,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`   // Vencimiento del lease actual (RFC3339)
+	LastUpdateHlc   string                 `protobuf:"bytes,10,opt,name=last_update_hlc,json=lastUpdateHlc,proto3" json:"last_update_hlc,omitempty"`     // Marca HLC de last_update, comparable entre máquinas
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ServerState) Reset() {
+	*x = ServerState{}
+	mi := &file_comunicacion_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerState) ProtoMessage() {}
+
+func (x *ServerState) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerState.ProtoReflect.Descriptor instead.
+func (*ServerState) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ServerState) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ServerState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ServerState) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ServerState) GetCurrentMatchId() int32 {
+	if x != nil {
+		return x.CurrentMatchId
+	}
+	return 0
+}
+
+func (x *ServerState) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ServerState) GetMatchesAssigned() int32 {
+	if x != nil {
+		return x.MatchesAssigned
+	}
+	return 0
+}
+
+func (x *ServerState) GetLastUpdate() string {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return ""
+}
+
+func (x *ServerState) GetLeaseExpired() bool {
+	if x != nil {
+		return x.LeaseExpired
+	}
+	return false
+}
+
+func (x *ServerState) GetLeaseExpiresAt() string {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return ""
+}
+
+func (x *ServerState) GetLastUpdateHlc() string {
+	if x != nil {
+		return x.LastUpdateHlc
+	}
+	return ""
+}
+
+type PlayerQueueEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`           // ID del jugador en la cola
+	TimeInQueue   string                 `protobuf:"bytes,2,opt,name=time_in_queue,json=timeInQueue,proto3" json:"time_in_queue,omitempty"` // Tiempo en cola (puede ser string o int64 para segundos)
+	GameMode      string                 `protobuf:"bytes,3,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`            // Modo de juego de la cola en la que espera el jugador
+	Rating        int32                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`                               // Rating de habilidad (Elo) del jugador
+	PartyId       int32                  `protobuf:"varint,5,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`              // ID del grupo con el que entró a la cola (0 si entró solo)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerQueueEntry) Reset() {
+	*x = PlayerQueueEntry{}
+	mi := &file_comunicacion_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerQueueEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerQueueEntry) ProtoMessage() {}
+
+func (x *PlayerQueueEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerQueueEntry.ProtoReflect.Descriptor instead.
+func (*PlayerQueueEntry) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PlayerQueueEntry) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *PlayerQueueEntry) GetTimeInQueue() string {
+	if x != nil {
+		return x.TimeInQueue
+	}
+	return ""
+}
+
+func (x *PlayerQueueEntry) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *PlayerQueueEntry) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *PlayerQueueEntry) GetPartyId() int32 {
+	if x != nil {
+		return x.PartyId
+	}
+	return 0
+}
+
+// Cola de un modo de juego: solo los jugadores de un mismo modo se emparejan entre sí
+type GameModeQueue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameMode      string                 `protobuf:"bytes,1,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"` // Modo de juego, por ejemplo "Casual" o "Ranked"
+	Players       []*PlayerQueueEntry    `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`                   // Jugadores en la cola de ese modo, en orden de llegada
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameModeQueue) Reset() {
+	*x = GameModeQueue{}
+	mi := &file_comunicacion_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameModeQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameModeQueue) ProtoMessage() {}
+
+func (x *GameModeQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameModeQueue.ProtoReflect.Descriptor instead.
+func (*GameModeQueue) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GameModeQueue) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *GameModeQueue) GetPlayers() []*PlayerQueueEntry {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+// ∗ SystemStatusResponse: Debe contener listas de ServerState (id, status, address, current match id) y PlayerQueueEntry (player id, time in queue).
+type SystemStatusResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Servers           []*ServerState         `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`                                              // Lista de estados de los servidores
+	PlayerQueue       []*PlayerQueueEntry    `protobuf:"bytes,2,rep,name=player_queue,json=playerQueue,proto3" json:"player_queue,omitempty"`                   // Lista de jugadores en cola
+	VectorClock       *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                   // Vector de reloj para la sincronización
+	GameModeQueues    []*GameModeQueue       `protobuf:"bytes,4,rep,name=game_mode_queues,json=gameModeQueues,proto3" json:"game_mode_queues,omitempty"`        // Colas de jugadores agrupadas por modo de juego
+	Matches           []*MatchInfo           `protobuf:"bytes,5,rep,name=matches,proto3" json:"matches,omitempty"`                                              // Partidas activas (por confirmar o en curso)
+	SelectionStrategy string                 `protobuf:"bytes,6,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"` // Estrategia de selección de servidores configurada
+	Connections       []*PeerConnection      `protobuf:"bytes,7,rep,name=connections,proto3" json:"connections,omitempty"`                                      // Conexiones del Matchmaker con los servidores de juego y las demás réplicas
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SystemStatusResponse) Reset() {
+	*x = SystemStatusResponse{}
+	mi := &file_comunicacion_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStatusResponse) ProtoMessage() {}
+
+func (x *SystemStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStatusResponse.ProtoReflect.Descriptor instead.
+func (*SystemStatusResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SystemStatusResponse) GetServers() []*ServerState {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetPlayerQueue() []*PlayerQueueEntry {
+	if x != nil {
+		return x.PlayerQueue
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetGameModeQueues() []*GameModeQueue {
+	if x != nil {
+		return x.GameModeQueues
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetMatches() []*MatchInfo {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetSelectionStrategy() string {
+	if x != nil {
+		return x.SelectionStrategy
+	}
+	return ""
+}
+
+func (x *SystemStatusResponse) GetConnections() []*PeerConnection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+// Conexión reutilizada del Matchmaker con un servidor de juego o con otra réplica.
+type PeerConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`                    // ID del servidor de juego o de la réplica
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`              // Dirección a la que se conecta
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`                  // Estado de la conexión: IDLE, CONNECTING, READY, TRANSIENT_FAILURE o SHUTDOWN
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`                  // Momento del último cambio de estado (RFC3339)
+	Reconnections int32                  `protobuf:"varint,5,opt,name=reconnections,proto3" json:"reconnections,omitempty"` // Veces que la conexión se restableció después de perderse
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerConnection) Reset() {
+	*x = PeerConnection{}
+	mi := &file_comunicacion_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerConnection) ProtoMessage() {}
+
+func (x *PeerConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerConnection.ProtoReflect.Descriptor instead.
+func (*PeerConnection) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PeerConnection) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *PeerConnection) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerConnection) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *PeerConnection) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *PeerConnection) GetReconnections() int32 {
+	if x != nil {
+		return x.Reconnections
+	}
+	return 0
+}
+
+// Mensajes para la actualización del estado del servidor por parte del Cliente Administrador
+type AdminServerUpdateRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                        // ID del servidor a actualizar
+	NewForcedStatus string                 `protobuf:"bytes,2,opt,name=new_forced_status,json=newForcedStatus,proto3" json:"new_forced_status,omitempty"` // Nuevo estado del servidor, por ejemplo, "DISPONIBLE", "CAIDO" o "DRAINING"
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AdminServerUpdateRequest) Reset() {
+	*x = AdminServerUpdateRequest{}
+	mi := &file_comunicacion_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminServerUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminServerUpdateRequest) ProtoMessage() {}
+
+func (x *AdminServerUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminServerUpdateRequest.ProtoReflect.Descriptor instead.
+func (*AdminServerUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AdminServerUpdateRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *AdminServerUpdateRequest) GetNewForcedStatus() string {
+	if x != nil {
+		return x.NewForcedStatus
+	}
+	return ""
+}
+
+type AdminUpdateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // Código de estado de la actualización, por ejemplo, "SUCCESS", "FAILURE"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                         // Mensaje adicional, por ejemplo, "Servidor actualizado correctamente"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUpdateResponse) Reset() {
+	*x = AdminUpdateResponse{}
+	mi := &file_comunicacion_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUpdateResponse) ProtoMessage() {}
+
+func (x *AdminUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUpdateResponse.ProtoReflect.Descriptor instead.
+func (*AdminUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AdminUpdateResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *AdminUpdateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Mensajes para la verificación de salud del servidor
+type ServerId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`          // ID o dirección del servidor a verificar
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerId) Reset() {
+	*x = ServerId{}
+	mi := &file_comunicacion_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerId) ProtoMessage() {}
+
+func (x *ServerId) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerId.ProtoReflect.Descriptor instead.
+func (*ServerId) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ServerId) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerId) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                              // Estado del servidor, por ejemplo, "ONLINE", "OFFLINE"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // Mensaje adicional, por ejemplo, "Servidor activo"
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_comunicacion_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PingResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PingResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PingResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Mensajes para la renovación del lease de un servidor de juego
+type LeaseRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                         // ID del servidor que renueva su lease
+	RenewIntervalMs int32                  `protobuf:"varint,2,opt,name=renew_interval_ms,json=renewIntervalMs,proto3" json:"renew_interval_ms,omitempty"` // Cada cuántos milisegundos renueva el servidor su lease
+	VectorClock     *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`                // Vector de reloj para la sincronización
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LeaseRequest) Reset() {
+	*x = LeaseRequest{}
+	mi := &file_comunicacion_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest) ProtoMessage() {}
+
+func (x *LeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LeaseRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *LeaseRequest) GetRenewIntervalMs() int32 {
+	if x != nil {
+		return x.RenewIntervalMs
+	}
+	return 0
+}
+
+func (x *LeaseRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type LeaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // "SUCCESS", o "FAILURE" si el servidor no está registrado
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // Vencimiento del lease concedido (RFC3339)
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	Drain         bool                   `protobuf:"varint,4,opt,name=drain,proto3" json:"drain,omitempty"`                               // true si el servidor debe pasar a DRAINING: terminar su partida, darse de baja y detenerse
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseResponse) Reset() {
+	*x = LeaseResponse{}
+	mi := &file_comunicacion_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseResponse) ProtoMessage() {}
+
+func (x *LeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseResponse.ProtoReflect.Descriptor instead.
+func (*LeaseResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *LeaseResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *LeaseResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *LeaseResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *LeaseResponse) GetDrain() bool {
+	if x != nil {
+		return x.Drain
+	}
+	return false
+}
+
+// Mensajes para la funcionalidad de baja de un servidor de juego
+type DeregisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // ID del servidor que se da de baja
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterRequest) Reset() {
+	*x = DeregisterRequest{}
+	mi := &file_comunicacion_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterRequest) ProtoMessage() {}
+
+func (x *DeregisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeregisterRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DeregisterRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type DeregisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`    // "SUCCESS", o "FAILURE" si el servidor todavía tiene una partida en curso
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                            // Mensaje adicional
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterResponse) Reset() {
+	*x = DeregisterResponse{}
+	mi := &file_comunicacion_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterResponse) ProtoMessage() {}
+
+func (x *DeregisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterResponse.ProtoReflect.Descriptor instead.
+func (*DeregisterResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeregisterResponse) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *DeregisterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeregisterResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type VectorClock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clocks        map[string]int32       `protobuf:"bytes,1,rep,name=clocks,proto3" json:"clocks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Ej: {"Player1": 1, "Matchmaker": 3, "GameServer1": 2}
+	Pruned        []string               `protobuf:"bytes,2,rep,name=pruned,proto3" json:"pruned,omitempty"`                                                                            // Procesos dados de baja cuyo componente se quitó de los relojes
+	HybridClock   *HybridClock           `protobuf:"bytes,3,opt,name=hybrid_clock,json=hybridClock,proto3" json:"hybrid_clock,omitempty"`                                               // Reloj lógico híbrido del remitente, para ordenar eventos totalmente cerca de la hora real
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorClock) Reset() {
+	*x = VectorClock{}
+	mi := &file_comunicacion_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorClock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorClock) ProtoMessage() {}
+
+func (x *VectorClock) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorClock.ProtoReflect.Descriptor instead.
+func (*VectorClock) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *VectorClock) GetClocks() map[string]int32 {
+	if x != nil {
+		return x.Clocks
+	}
+	return nil
+}
+
+func (x *VectorClock) GetPruned() []string {
+	if x != nil {
+		return x.Pruned
+	}
+	return nil
+}
+
+func (x *VectorClock) GetHybridClock() *HybridClock {
+	if x != nil {
+		return x.HybridClock
+	}
+	return nil
+}
+
+// Marca de un reloj lógico híbrido (HLC)
+type HybridClock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PhysicalMs    int64                  `protobuf:"varint,1,opt,name=physical_ms,json=physicalMs,proto3" json:"physical_ms,omitempty"` // Mayor hora física conocida (milisegundos desde la época Unix)
+	Logical       int32                  `protobuf:"varint,2,opt,name=logical,proto3" json:"logical,omitempty"`                         // Contador de eventos dentro del mismo milisegundo
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HybridClock) Reset() {
+	*x = HybridClock{}
+	mi := &file_comunicacion_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HybridClock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HybridClock) ProtoMessage() {}
+
+func (x *HybridClock) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HybridClock.ProtoReflect.Descriptor instead.
+func (*HybridClock) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *HybridClock) GetPhysicalMs() int64 {
+	if x != nil {
+		return x.PhysicalMs
+	}
+	return 0
+}
+
+func (x *HybridClock) GetLogical() int32 {
+	if x != nil {
+		return x.Logical
+	}
+	return 0
+}
+
+// jugador
+type Jugador struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                            // ID del jugador
+	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                         // Nombre del jugador
+	GameModePreference string                 `protobuf:"bytes,3,opt,name=game_mode_preference,json=gameModePreference,proto3" json:"game_mode_preference,omitempty"` // Preferencia de modo de juego del jugador
+	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                                     // Estado del jugador, por ejemplo, "IDLE", "IN QUEUE", "IN MATCH"
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Jugador) Reset() {
+	*x = Jugador{}
+	mi := &file_comunicacion_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Jugador) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Jugador) ProtoMessage() {}
+
+func (x *Jugador) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Jugador.ProtoReflect.Descriptor instead.
+func (*Jugador) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Jugador) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Jugador) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Jugador) GetGameModePreference() string {
+	if x != nil {
+		return x.GameModePreference
+	}
+	return ""
+}
+
+func (x *Jugador) GetStatus() string {
 	if x != nil {
-		return x.TimeInQueue
+		return x.Status
 	}
 	return ""
 }
 
-// ∗ SystemStatusResponse: Debe contener listas de ServerState (id, status, address, current match id) y PlayerQueueEntry (player id, time in queue).
-type SystemStatusResponse struct {
+// Mensajes para el consenso (Raft) entre las réplicas del Matchmaker
+type VoteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Servers       []*ServerState         `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`                            // Lista de estados de los servidores
-	PlayerQueue   []*PlayerQueueEntry    `protobuf:"bytes,2,rep,name=player_queue,json=playerQueue,proto3" json:"player_queue,omitempty"` // Lista de jugadores en cola
-	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // Vector de reloj para la sincronización
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`                                       // Término del candidato
+	CandidateId   string                 `protobuf:"bytes,2,opt,name=candidate_id,json=candidateId,proto3" json:"candidate_id,omitempty"`       // ID de la réplica que pide el voto
+	LastLogIndex  int64                  `protobuf:"varint,3,opt,name=last_log_index,json=lastLogIndex,proto3" json:"last_log_index,omitempty"` // Índice de la última entrada del log del candidato
+	LastLogTerm   int64                  `protobuf:"varint,4,opt,name=last_log_term,json=lastLogTerm,proto3" json:"last_log_term,omitempty"`    // Término de la última entrada del log del candidato
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SystemStatusResponse) Reset() {
-	*x = SystemStatusResponse{}
-	mi := &file_comunicacion_proto_msgTypes[11]
+func (x *VoteRequest) Reset() {
+	*x = VoteRequest{}
+	mi := &file_comunicacion_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemStatusResponse) String() string {
+func (x *VoteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemStatusResponse) ProtoMessage() {}
+func (*VoteRequest) ProtoMessage() {}
 
-func (x *SystemStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[11]
+func (x *VoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,56 +2514,62 @@ func (x *SystemStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemStatusResponse.ProtoReflect.Descriptor instead.
-func (*SystemStatusResponse) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use VoteRequest.ProtoReflect.Descriptor instead.
+func (*VoteRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *SystemStatusResponse) GetServers() []*ServerState {
+func (x *VoteRequest) GetTerm() int64 {
 	if x != nil {
-		return x.Servers
+		return x.Term
 	}
-	return nil
+	return 0
 }
 
-func (x *SystemStatusResponse) GetPlayerQueue() []*PlayerQueueEntry {
+func (x *VoteRequest) GetCandidateId() string {
 	if x != nil {
-		return x.PlayerQueue
+		return x.CandidateId
 	}
-	return nil
+	return ""
 }
 
-func (x *SystemStatusResponse) GetVectorClock() *VectorClock {
+func (x *VoteRequest) GetLastLogIndex() int64 {
 	if x != nil {
-		return x.VectorClock
+		return x.LastLogIndex
 	}
-	return nil
+	return 0
 }
 
-// Mensajes para la actualización del estado del servidor por parte del Cliente Administrador
-type AdminServerUpdateRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`                        // ID del servidor a actualizar
-	NewForcedStatus string                 `protobuf:"bytes,2,opt,name=new_forced_status,json=newForcedStatus,proto3" json:"new_forced_status,omitempty"` // Nuevo estado del servidor, por ejemplo, "DISPONIBLE", "CAIDO"
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *VoteRequest) GetLastLogTerm() int64 {
+	if x != nil {
+		return x.LastLogTerm
+	}
+	return 0
 }
 
-func (x *AdminServerUpdateRequest) Reset() {
-	*x = AdminServerUpdateRequest{}
-	mi := &file_comunicacion_proto_msgTypes[12]
+type VoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`                                  // Término actual de la réplica que vota
+	VoteGranted   bool                   `protobuf:"varint,2,opt,name=vote_granted,json=voteGranted,proto3" json:"vote_granted,omitempty"` // true si la réplica votó por el candidato
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoteResponse) Reset() {
+	*x = VoteResponse{}
+	mi := &file_comunicacion_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminServerUpdateRequest) String() string {
+func (x *VoteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminServerUpdateRequest) ProtoMessage() {}
+func (*VoteResponse) ProtoMessage() {}
 
-func (x *AdminServerUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[12]
+func (x *VoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,48 +2580,49 @@ func (x *AdminServerUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminServerUpdateRequest.ProtoReflect.Descriptor instead.
-func (*AdminServerUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use VoteResponse.ProtoReflect.Descriptor instead.
+func (*VoteResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *AdminServerUpdateRequest) GetServerId() string {
+func (x *VoteResponse) GetTerm() int64 {
 	if x != nil {
-		return x.ServerId
+		return x.Term
 	}
-	return ""
+	return 0
 }
 
-func (x *AdminServerUpdateRequest) GetNewForcedStatus() string {
+func (x *VoteResponse) GetVoteGranted() bool {
 	if x != nil {
-		return x.NewForcedStatus
+		return x.VoteGranted
 	}
-	return ""
+	return false
 }
 
-type AdminUpdateResponse struct {
+type LogEntry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StatusCode    string                 `protobuf:"bytes,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // Código de estado de la actualización, por ejemplo, "SUCCESS", "FAILURE"
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                         // Mensaje adicional, por ejemplo, "Servidor actualizado correctamente"
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Posición de la entrada en el log
+	Term          int64                  `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`   // Término en que el líder creó la entrada
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`    // Cambios de estado (vacío en la entrada inicial de cada líder)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminUpdateResponse) Reset() {
-	*x = AdminUpdateResponse{}
-	mi := &file_comunicacion_proto_msgTypes[13]
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_comunicacion_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminUpdateResponse) String() string {
+func (x *LogEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminUpdateResponse) ProtoMessage() {}
+func (*LogEntry) ProtoMessage() {}
 
-func (x *AdminUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[13]
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -817,48 +2633,60 @@ func (x *AdminUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminUpdateResponse.ProtoReflect.Descriptor instead.
-func (*AdminUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *AdminUpdateResponse) GetStatusCode() string {
+func (x *LogEntry) GetIndex() int64 {
 	if x != nil {
-		return x.StatusCode
+		return x.Index
 	}
-	return ""
+	return 0
 }
 
-func (x *AdminUpdateResponse) GetMessage() string {
+func (x *LogEntry) GetTerm() int64 {
 	if x != nil {
-		return x.Message
+		return x.Term
 	}
-	return ""
+	return 0
 }
 
-// Mensajes para la verificación de salud del servidor
-type ServerId struct {
+func (x *LogEntry) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AppendEntriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // ID o dirección del servidor a verificar
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`                                       // Término del líder
+	LeaderId      string                 `protobuf:"bytes,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`                // ID del líder
+	LeaderAddress string                 `protobuf:"bytes,3,opt,name=leader_address,json=leaderAddress,proto3" json:"leader_address,omitempty"` // Dirección del líder, para redirigir a los clientes
+	PrevLogIndex  int64                  `protobuf:"varint,4,opt,name=prev_log_index,json=prevLogIndex,proto3" json:"prev_log_index,omitempty"` // Índice de la entrada anterior a las enviadas
+	PrevLogTerm   int64                  `protobuf:"varint,5,opt,name=prev_log_term,json=prevLogTerm,proto3" json:"prev_log_term,omitempty"`    // Término de la entrada anterior a las enviadas
+	Entries       []*LogEntry            `protobuf:"bytes,6,rep,name=entries,proto3" json:"entries,omitempty"`                                  // Entradas a agregar (vacío en los latidos)
+	LeaderCommit  int64                  `protobuf:"varint,7,opt,name=leader_commit,json=leaderCommit,proto3" json:"leader_commit,omitempty"`   // Último índice confirmado por el líder
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServerId) Reset() {
-	*x = ServerId{}
-	mi := &file_comunicacion_proto_msgTypes[14]
+func (x *AppendEntriesRequest) Reset() {
+	*x = AppendEntriesRequest{}
+	mi := &file_comunicacion_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerId) String() string {
+func (x *AppendEntriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerId) ProtoMessage() {}
+func (*AppendEntriesRequest) ProtoMessage() {}
 
-func (x *ServerId) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[14]
+func (x *AppendEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,92 +2697,84 @@ func (x *ServerId) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerId.ProtoReflect.Descriptor instead.
-func (*ServerId) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use AppendEntriesRequest.ProtoReflect.Descriptor instead.
+func (*AppendEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ServerId) GetServerId() string {
+func (x *AppendEntriesRequest) GetTerm() int64 {
 	if x != nil {
-		return x.ServerId
+		return x.Term
 	}
-	return ""
-}
-
-type PingResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`   // Estado del servidor, por ejemplo, "ONLINE", "OFFLINE"
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // Mensaje adicional, por ejemplo, "Servidor activo"
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return 0
 }
 
-func (x *PingResponse) Reset() {
-	*x = PingResponse{}
-	mi := &file_comunicacion_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *AppendEntriesRequest) GetLeaderId() string {
+	if x != nil {
+		return x.LeaderId
+	}
+	return ""
 }
 
-func (x *PingResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AppendEntriesRequest) GetLeaderAddress() string {
+	if x != nil {
+		return x.LeaderAddress
+	}
+	return ""
 }
 
-func (*PingResponse) ProtoMessage() {}
-
-func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[15]
+func (x *AppendEntriesRequest) GetPrevLogIndex() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.PrevLogIndex
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
-func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{15}
+func (x *AppendEntriesRequest) GetPrevLogTerm() int64 {
+	if x != nil {
+		return x.PrevLogTerm
+	}
+	return 0
 }
 
-func (x *PingResponse) GetStatus() string {
+func (x *AppendEntriesRequest) GetEntries() []*LogEntry {
 	if x != nil {
-		return x.Status
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-func (x *PingResponse) GetMessage() string {
+func (x *AppendEntriesRequest) GetLeaderCommit() int64 {
 	if x != nil {
-		return x.Message
+		return x.LeaderCommit
 	}
-	return ""
+	return 0
 }
 
-type VectorClock struct {
+type AppendEntriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Clocks        map[string]int32       `protobuf:"bytes,1,rep,name=clocks,proto3" json:"clocks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Ej: {"Player1": 1, "Matchmaker": 3, "GameServer1": 2}
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`                                       // Término actual de la réplica
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`                                 // true si la réplica tenía la entrada anterior y agregó las nuevas
+	LastLogIndex  int64                  `protobuf:"varint,3,opt,name=last_log_index,json=lastLogIndex,proto3" json:"last_log_index,omitempty"` // Último índice del log de la réplica, para retroceder más rápido
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VectorClock) Reset() {
-	*x = VectorClock{}
-	mi := &file_comunicacion_proto_msgTypes[16]
+func (x *AppendEntriesResponse) Reset() {
+	*x = AppendEntriesResponse{}
+	mi := &file_comunicacion_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VectorClock) String() string {
+func (x *AppendEntriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VectorClock) ProtoMessage() {}
+func (*AppendEntriesResponse) ProtoMessage() {}
 
-func (x *VectorClock) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[16]
+func (x *AppendEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -965,44 +2785,59 @@ func (x *VectorClock) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VectorClock.ProtoReflect.Descriptor instead.
-func (*VectorClock) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use AppendEntriesResponse.ProtoReflect.Descriptor instead.
+func (*AppendEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *VectorClock) GetClocks() map[string]int32 {
+func (x *AppendEntriesResponse) GetTerm() int64 {
 	if x != nil {
-		return x.Clocks
+		return x.Term
 	}
-	return nil
+	return 0
 }
 
-// jugador
-type Jugador struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                                                            // ID del jugador
-	Name               string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                         // Nombre del jugador
-	GameModePreference string                 `protobuf:"bytes,3,opt,name=game_mode_preference,json=gameModePreference,proto3" json:"game_mode_preference,omitempty"` // Preferencia de modo de juego del jugador
-	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                                     // Estado del jugador, por ejemplo, "IDLE", "IN QUEUE", "IN MATCH"
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *AppendEntriesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
 }
 
-func (x *Jugador) Reset() {
-	*x = Jugador{}
-	mi := &file_comunicacion_proto_msgTypes[17]
+func (x *AppendEntriesResponse) GetLastLogIndex() int64 {
+	if x != nil {
+		return x.LastLogIndex
+	}
+	return 0
+}
+
+type InstallSnapshotRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Term              int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`                                                      // Término del líder
+	LeaderId          string                 `protobuf:"bytes,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`                               // ID del líder
+	LeaderAddress     string                 `protobuf:"bytes,3,opt,name=leader_address,json=leaderAddress,proto3" json:"leader_address,omitempty"`                // Dirección del líder
+	LastIncludedIndex int64                  `protobuf:"varint,4,opt,name=last_included_index,json=lastIncludedIndex,proto3" json:"last_included_index,omitempty"` // Último índice incluido en el snapshot
+	LastIncludedTerm  int64                  `protobuf:"varint,5,opt,name=last_included_term,json=lastIncludedTerm,proto3" json:"last_included_term,omitempty"`    // Término de ese índice
+	Data              []byte                 `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`                                                       // Estado completo del Matchmaker en ese índice
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InstallSnapshotRequest) Reset() {
+	*x = InstallSnapshotRequest{}
+	mi := &file_comunicacion_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Jugador) String() string {
+func (x *InstallSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Jugador) ProtoMessage() {}
+func (*InstallSnapshotRequest) ProtoMessage() {}
 
-func (x *Jugador) ProtoReflect() protoreflect.Message {
-	mi := &file_comunicacion_proto_msgTypes[17]
+func (x *InstallSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1013,37 +2848,95 @@ func (x *Jugador) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Jugador.ProtoReflect.Descriptor instead.
-func (*Jugador) Descriptor() ([]byte, []int) {
-	return file_comunicacion_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use InstallSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *Jugador) GetId() int32 {
+func (x *InstallSnapshotRequest) GetTerm() int64 {
 	if x != nil {
-		return x.Id
+		return x.Term
 	}
 	return 0
 }
 
-func (x *Jugador) GetName() string {
+func (x *InstallSnapshotRequest) GetLeaderId() string {
 	if x != nil {
-		return x.Name
+		return x.LeaderId
 	}
 	return ""
 }
 
-func (x *Jugador) GetGameModePreference() string {
+func (x *InstallSnapshotRequest) GetLeaderAddress() string {
 	if x != nil {
-		return x.GameModePreference
+		return x.LeaderAddress
 	}
 	return ""
 }
 
-func (x *Jugador) GetStatus() string {
+func (x *InstallSnapshotRequest) GetLastIncludedIndex() int64 {
 	if x != nil {
-		return x.Status
+		return x.LastIncludedIndex
 	}
-	return ""
+	return 0
+}
+
+func (x *InstallSnapshotRequest) GetLastIncludedTerm() int64 {
+	if x != nil {
+		return x.LastIncludedTerm
+	}
+	return 0
+}
+
+func (x *InstallSnapshotRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type InstallSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          int64                  `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"` // Término actual de la réplica
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallSnapshotResponse) Reset() {
+	*x = InstallSnapshotResponse{}
+	mi := &file_comunicacion_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallSnapshotResponse) ProtoMessage() {}
+
+func (x *InstallSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comunicacion_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_comunicacion_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *InstallSnapshotResponse) GetTerm() int64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
 }
 
 var File_comunicacion_proto protoreflect.FileDescriptor
@@ -1057,81 +2950,255 @@ const file_comunicacion_proto_rawDesc = "" +
 	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"m\n" +
 	"\x13QueuePlayerResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12<\n" +
-	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"2\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"n\n" +
+	"\x11LeaveQueueRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12<\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x8d\x01\n" +
+	"\x12LeaveQueueResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xa1\x01\n" +
+	"\x11ReadyCheckRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\x05R\amatchId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\x12<\n" +
+	"\fvector_clock\x18\x04 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x8d\x01\n" +
+	"\x12ReadyCheckResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x89\x01\n" +
+	"\x0fWaitTimeRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x8d\x02\n" +
+	"\x10WaitTimeResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\"\n" +
+	"\rtime_in_queue\x18\x03 \x01(\tR\vtimeInQueue\x124\n" +
+	"\x16estimated_wait_seconds\x18\x04 \x01(\x05R\x14estimatedWaitSeconds\x12#\n" +
+	"\rplayers_ahead\x18\x05 \x01(\x05R\fplayersAhead\x12<\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"p\n" +
 	"\x13PlayerStatusRequest\x12\x1b\n" +
-	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\"\xb9\x01\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12<\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xd4\x02\n" +
 	"\x14PlayerStatusResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
 	"\bmatch_id\x18\x04 \x01(\x05R\amatchId\x120\n" +
 	"\x14match_server_address\x18\x05 \x01(\tR\x12matchServerAddress\x12<\n" +
-	"\fvector_clock\x18\x06 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x8e\x01\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\x12\x16\n" +
+	"\x06rating\x18\a \x01(\x05R\x06rating\x12\x19\n" +
+	"\bparty_id\x18\b \x01(\x05R\apartyId\x127\n" +
+	"\x18ready_check_seconds_left\x18\t \x01(\x05R\x15readyCheckSecondsLeft\x12-\n" +
+	"\x05match\x18\n" +
+	" \x01(\v2\x17.comunicacion.MatchInfoR\x05match\"\x84\x01\n" +
+	"\fPartyRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12\x19\n" +
+	"\bparty_id\x18\x02 \x01(\x05R\apartyId\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xe1\x01\n" +
+	"\rPartyResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bparty_id\x18\x03 \x01(\x05R\apartyId\x12\x1b\n" +
+	"\tleader_id\x18\x04 \x01(\x05R\bleaderId\x12\x1f\n" +
+	"\vmembers_ids\x18\x05 \x03(\x05R\n" +
+	"membersIds\x12<\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xba\x01\n" +
 	"\x12AssignMatchRequest\x12\x19\n" +
-	"\bmatch_id\x18\x01 \x01(\x05R\amatchId\x12\x1f\n" +
+	"\bmatch_id\x18\x01 \x01(\x05R\amatchId\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\x12\x1b\n" +
+	"\tgame_mode\x18\x04 \x01(\tR\bgameMode\x12(\n" +
+	"\x05teams\x18\x05 \x03(\v2\x12.comunicacion.TeamR\x05teamsJ\x04\b\x02\x10\x03\"@\n" +
+	"\x04Team\x12\x17\n" +
+	"\ateam_id\x18\x01 \x01(\x05R\x06teamId\x12\x1f\n" +
 	"\vplayers_ids\x18\x02 \x03(\x05R\n" +
-	"playersIds\x12<\n" +
-	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xdb\x01\n" +
+	"playersIds\"\xe8\x01\n" +
+	"\tMatchInfo\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x05R\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12%\n" +
+	"\x0eserver_address\x18\x03 \x01(\tR\rserverAddress\x12\x1b\n" +
+	"\tgame_mode\x18\x04 \x01(\tR\bgameMode\x12(\n" +
+	"\x05teams\x18\x05 \x03(\v2\x12.comunicacion.TeamR\x05teams\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\a \x01(\tR\tstartTime\"\xe1\x01\n" +
 	"\x13AssignMatchResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x19\n" +
 	"\bmatch_id\x18\x02 \x01(\x05R\amatchId\x12\x1f\n" +
 	"\vplayers_ids\x18\x03 \x03(\x05R\n" +
 	"playersIds\x120\n" +
 	"\x14match_server_address\x18\x04 \x01(\tR\x12matchServerAddress\x12<\n" +
-	"\fvector_clock\x18\x05 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xaf\x01\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClockJ\x04\b\x06\x10\a\"\xd3\x01\n" +
+	"\x12MatchResultRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x05R\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12&\n" +
+	"\x0fwinning_team_id\x18\x03 \x01(\x05R\rwinningTeamId\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12<\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x8e\x01\n" +
+	"\x13MatchResultResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xcb\x01\n" +
 	"\x19ServerStatusUpdateRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1d\n" +
 	"\n" +
 	"new_status\x18\x02 \x01(\tR\tnewStatus\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12<\n" +
-	"\fvector_clock\x18\x04 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"{\n" +
+	"\fvector_clock\x18\x04 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\"{\n" +
 	"\x1aServerStatusUpdateResponse\x12\x1f\n" +
 	"\vstatus_code\x18\x01 \x01(\tR\n" +
 	"statusCode\x12<\n" +
 	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\")\n" +
 	"\fAdminRequest\x12\x19\n" +
-	"\badmin_id\x18\x01 \x01(\tR\aadminId\"y\n" +
+	"\badmin_id\x18\x01 \x01(\tR\aadminId\"\xd8\x02\n" +
 	"\vServerState\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12(\n" +
-	"\x10current_match_id\x18\x04 \x01(\x05R\x0ecurrentMatchId\"S\n" +
+	"\x10current_match_id\x18\x04 \x01(\x05R\x0ecurrentMatchId\x12\x1a\n" +
+	"\bcapacity\x18\x05 \x01(\x05R\bcapacity\x12)\n" +
+	"\x10matches_assigned\x18\x06 \x01(\x05R\x0fmatchesAssigned\x12\x1f\n" +
+	"\vlast_update\x18\a \x01(\tR\n" +
+	"lastUpdate\x12#\n" +
+	"\rlease_expired\x18\b \x01(\bR\fleaseExpired\x12(\n" +
+	"\x10lease_expires_at\x18\t \x01(\tR\x0eleaseExpiresAt\x12&\n" +
+	"\x0flast_update_hlc\x18\n" +
+	" \x01(\tR\rlastUpdateHlc\"\xa3\x01\n" +
 	"\x10PlayerQueueEntry\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12\"\n" +
-	"\rtime_in_queue\x18\x02 \x01(\tR\vtimeInQueue\"\xcc\x01\n" +
+	"\rtime_in_queue\x18\x02 \x01(\tR\vtimeInQueue\x12\x1b\n" +
+	"\tgame_mode\x18\x03 \x01(\tR\bgameMode\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x05R\x06rating\x12\x19\n" +
+	"\bparty_id\x18\x05 \x01(\x05R\apartyId\"f\n" +
+	"\rGameModeQueue\x12\x1b\n" +
+	"\tgame_mode\x18\x01 \x01(\tR\bgameMode\x128\n" +
+	"\aplayers\x18\x02 \x03(\v2\x1e.comunicacion.PlayerQueueEntryR\aplayers\"\xb5\x03\n" +
 	"\x14SystemStatusResponse\x123\n" +
 	"\aservers\x18\x01 \x03(\v2\x19.comunicacion.ServerStateR\aservers\x12A\n" +
 	"\fplayer_queue\x18\x02 \x03(\v2\x1e.comunicacion.PlayerQueueEntryR\vplayerQueue\x12<\n" +
-	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"c\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\x12E\n" +
+	"\x10game_mode_queues\x18\x04 \x03(\v2\x1b.comunicacion.GameModeQueueR\x0egameModeQueues\x121\n" +
+	"\amatches\x18\x05 \x03(\v2\x17.comunicacion.MatchInfoR\amatches\x12-\n" +
+	"\x12selection_strategy\x18\x06 \x01(\tR\x11selectionStrategy\x12>\n" +
+	"\vconnections\x18\a \x03(\v2\x1c.comunicacion.PeerConnectionR\vconnections\"\x90\x01\n" +
+	"\x0ePeerConnection\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12$\n" +
+	"\rreconnections\x18\x05 \x01(\x05R\rreconnections\"c\n" +
 	"\x18AdminServerUpdateRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12*\n" +
 	"\x11new_forced_status\x18\x02 \x01(\tR\x0fnewForcedStatus\"P\n" +
 	"\x13AdminUpdateResponse\x12\x1f\n" +
 	"\vstatus_code\x18\x01 \x01(\tR\n" +
 	"statusCode\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"'\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"e\n" +
 	"\bServerId\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\"@\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12<\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"~\n" +
 	"\fPingResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x87\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x95\x01\n" +
+	"\fLeaseRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12*\n" +
+	"\x11renew_interval_ms\x18\x02 \x01(\x05R\x0frenewIntervalMs\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xa3\x01\n" +
+	"\rLeaseResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\x12\x14\n" +
+	"\x05drain\x18\x04 \x01(\bR\x05drain\"n\n" +
+	"\x11DeregisterRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12<\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\x8d\x01\n" +
+	"\x12DeregisterResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\tR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12<\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x19.comunicacion.VectorClockR\vvectorClock\"\xdd\x01\n" +
 	"\vVectorClock\x12=\n" +
-	"\x06clocks\x18\x01 \x03(\v2%.comunicacion.VectorClock.ClocksEntryR\x06clocks\x1a9\n" +
+	"\x06clocks\x18\x01 \x03(\v2%.comunicacion.VectorClock.ClocksEntryR\x06clocks\x12\x16\n" +
+	"\x06pruned\x18\x02 \x03(\tR\x06pruned\x12<\n" +
+	"\fhybrid_clock\x18\x03 \x01(\v2\x19.comunicacion.HybridClockR\vhybridClock\x1a9\n" +
 	"\vClocksEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"w\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"H\n" +
+	"\vHybridClock\x12\x1f\n" +
+	"\vphysical_ms\x18\x01 \x01(\x03R\n" +
+	"physicalMs\x12\x18\n" +
+	"\alogical\x18\x02 \x01(\x05R\alogical\"w\n" +
 	"\aJugador\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
 	"\x14game_mode_preference\x18\x03 \x01(\tR\x12gameModePreference\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status2\xfe\x04\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\x8e\x01\n" +
+	"\vVoteRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12!\n" +
+	"\fcandidate_id\x18\x02 \x01(\tR\vcandidateId\x12$\n" +
+	"\x0elast_log_index\x18\x03 \x01(\x03R\flastLogIndex\x12\"\n" +
+	"\rlast_log_term\x18\x04 \x01(\x03R\vlastLogTerm\"E\n" +
+	"\fVoteResponse\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12!\n" +
+	"\fvote_granted\x18\x02 \x01(\bR\vvoteGranted\"H\n" +
+	"\bLogEntry\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x12\n" +
+	"\x04term\x18\x02 \x01(\x03R\x04term\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x8f\x02\n" +
+	"\x14AppendEntriesRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x1b\n" +
+	"\tleader_id\x18\x02 \x01(\tR\bleaderId\x12%\n" +
+	"\x0eleader_address\x18\x03 \x01(\tR\rleaderAddress\x12$\n" +
+	"\x0eprev_log_index\x18\x04 \x01(\x03R\fprevLogIndex\x12\"\n" +
+	"\rprev_log_term\x18\x05 \x01(\x03R\vprevLogTerm\x120\n" +
+	"\aentries\x18\x06 \x03(\v2\x16.comunicacion.LogEntryR\aentries\x12#\n" +
+	"\rleader_commit\x18\a \x01(\x03R\fleaderCommit\"k\n" +
+	"\x15AppendEntriesResponse\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12$\n" +
+	"\x0elast_log_index\x18\x03 \x01(\x03R\flastLogIndex\"\xe2\x01\n" +
+	"\x16InstallSnapshotRequest\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term\x12\x1b\n" +
+	"\tleader_id\x18\x02 \x01(\tR\bleaderId\x12%\n" +
+	"\x0eleader_address\x18\x03 \x01(\tR\rleaderAddress\x12.\n" +
+	"\x13last_included_index\x18\x04 \x01(\x03R\x11lastIncludedIndex\x12,\n" +
+	"\x12last_included_term\x18\x05 \x01(\x03R\x10lastIncludedTerm\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\fR\x04data\"-\n" +
+	"\x17InstallSnapshotResponse\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\x03R\x04term2\xc7\f\n" +
 	"\x13ComunicacionService\x12Q\n" +
 	"\vQueuePlayer\x12\x1f.comunicacion.PlayerInfoRequest\x1a!.comunicacion.QueuePlayerResponse\x12X\n" +
-	"\x0fGetPlayerStatus\x12!.comunicacion.PlayerStatusRequest\x1a\".comunicacion.PlayerStatusResponse\x12R\n" +
-	"\vAssignMatch\x12 .comunicacion.AssignMatchRequest\x1a!.comunicacion.AssignMatchResponse\x12g\n" +
+	"\x0fGetPlayerStatus\x12!.comunicacion.PlayerStatusRequest\x1a\".comunicacion.PlayerStatusResponse\x12O\n" +
+	"\n" +
+	"LeaveQueue\x12\x1f.comunicacion.LeaveQueueRequest\x1a .comunicacion.LeaveQueueResponse\x12V\n" +
+	"\x11RespondReadyCheck\x12\x1f.comunicacion.ReadyCheckRequest\x1a .comunicacion.ReadyCheckResponse\x12Q\n" +
+	"\x10EstimateWaitTime\x12\x1d.comunicacion.WaitTimeRequest\x1a\x1e.comunicacion.WaitTimeResponse\x12F\n" +
+	"\vCreateParty\x12\x1a.comunicacion.PartyRequest\x1a\x1b.comunicacion.PartyResponse\x12D\n" +
+	"\tJoinParty\x12\x1a.comunicacion.PartyRequest\x1a\x1b.comunicacion.PartyResponse\x12E\n" +
+	"\n" +
+	"LeaveParty\x12\x1a.comunicacion.PartyRequest\x1a\x1b.comunicacion.PartyResponse\x12R\n" +
+	"\vAssignMatch\x12 .comunicacion.AssignMatchRequest\x1a!.comunicacion.AssignMatchResponse\x12X\n" +
+	"\x11ReportMatchResult\x12 .comunicacion.MatchResultRequest\x1a!.comunicacion.MatchResultResponse\x12g\n" +
 	"\x12UpdateServerStatus\x12'.comunicacion.ServerStatusUpdateRequest\x1a(.comunicacion.ServerStatusUpdateResponse\x12V\n" +
 	"\x14AdminGetSystemStatus\x12\x1a.comunicacion.AdminRequest\x1a\".comunicacion.SystemStatusResponse\x12c\n" +
 	"\x16AdminUpdateServerState\x12&.comunicacion.AdminServerUpdateRequest\x1a!.comunicacion.AdminUpdateResponse\x12@\n" +
 	"\n" +
-	"PingServer\x12\x16.comunicacion.ServerId\x1a\x1a.comunicacion.PingResponseB\x13Z\x11grpc-server/protob\x06proto3"
+	"PingServer\x12\x16.comunicacion.ServerId\x1a\x1a.comunicacion.PingResponse\x12E\n" +
+	"\n" +
+	"RenewLease\x12\x1a.comunicacion.LeaseRequest\x1a\x1b.comunicacion.LeaseResponse\x12U\n" +
+	"\x10DeregisterServer\x12\x1f.comunicacion.DeregisterRequest\x1a .comunicacion.DeregisterResponse\x12D\n" +
+	"\vRequestVote\x12\x19.comunicacion.VoteRequest\x1a\x1a.comunicacion.VoteResponse\x12X\n" +
+	"\rAppendEntries\x12\".comunicacion.AppendEntriesRequest\x1a#.comunicacion.AppendEntriesResponse\x12^\n" +
+	"\x0fInstallSnapshot\x12$.comunicacion.InstallSnapshotRequest\x1a%.comunicacion.InstallSnapshotResponseB\x13Z\x11grpc-server/protob\x06proto3"
 
 var (
 	file_comunicacion_proto_rawDescOnce sync.Once
@@ -1145,59 +3212,135 @@ func file_comunicacion_proto_rawDescGZIP() []byte {
 	return file_comunicacion_proto_rawDescData
 }
 
-var file_comunicacion_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_comunicacion_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_comunicacion_proto_goTypes = []any{
 	(*PlayerInfoRequest)(nil),          // 0: comunicacion.PlayerInfoRequest
 	(*QueuePlayerResponse)(nil),        // 1: comunicacion.QueuePlayerResponse
-	(*PlayerStatusRequest)(nil),        // 2: comunicacion.PlayerStatusRequest
-	(*PlayerStatusResponse)(nil),       // 3: comunicacion.PlayerStatusResponse
-	(*AssignMatchRequest)(nil),         // 4: comunicacion.AssignMatchRequest
-	(*AssignMatchResponse)(nil),        // 5: comunicacion.AssignMatchResponse
-	(*ServerStatusUpdateRequest)(nil),  // 6: comunicacion.ServerStatusUpdateRequest
-	(*ServerStatusUpdateResponse)(nil), // 7: comunicacion.ServerStatusUpdateResponse
-	(*AdminRequest)(nil),               // 8: comunicacion.AdminRequest
-	(*ServerState)(nil),                // 9: comunicacion.ServerState
-	(*PlayerQueueEntry)(nil),           // 10: comunicacion.PlayerQueueEntry
-	(*SystemStatusResponse)(nil),       // 11: comunicacion.SystemStatusResponse
-	(*AdminServerUpdateRequest)(nil),   // 12: comunicacion.AdminServerUpdateRequest
-	(*AdminUpdateResponse)(nil),        // 13: comunicacion.AdminUpdateResponse
-	(*ServerId)(nil),                   // 14: comunicacion.ServerId
-	(*PingResponse)(nil),               // 15: comunicacion.PingResponse
-	(*VectorClock)(nil),                // 16: comunicacion.VectorClock
-	(*Jugador)(nil),                    // 17: comunicacion.Jugador
-	nil,                                // 18: comunicacion.VectorClock.ClocksEntry
+	(*LeaveQueueRequest)(nil),          // 2: comunicacion.LeaveQueueRequest
+	(*LeaveQueueResponse)(nil),         // 3: comunicacion.LeaveQueueResponse
+	(*ReadyCheckRequest)(nil),          // 4: comunicacion.ReadyCheckRequest
+	(*ReadyCheckResponse)(nil),         // 5: comunicacion.ReadyCheckResponse
+	(*WaitTimeRequest)(nil),            // 6: comunicacion.WaitTimeRequest
+	(*WaitTimeResponse)(nil),           // 7: comunicacion.WaitTimeResponse
+	(*PlayerStatusRequest)(nil),        // 8: comunicacion.PlayerStatusRequest
+	(*PlayerStatusResponse)(nil),       // 9: comunicacion.PlayerStatusResponse
+	(*PartyRequest)(nil),               // 10: comunicacion.PartyRequest
+	(*PartyResponse)(nil),              // 11: comunicacion.PartyResponse
+	(*AssignMatchRequest)(nil),         // 12: comunicacion.AssignMatchRequest
+	(*Team)(nil),                       // 13: comunicacion.Team
+	(*MatchInfo)(nil),                  // 14: comunicacion.MatchInfo
+	(*AssignMatchResponse)(nil),        // 15: comunicacion.AssignMatchResponse
+	(*MatchResultRequest)(nil),         // 16: comunicacion.MatchResultRequest
+	(*MatchResultResponse)(nil),        // 17: comunicacion.MatchResultResponse
+	(*ServerStatusUpdateRequest)(nil),  // 18: comunicacion.ServerStatusUpdateRequest
+	(*ServerStatusUpdateResponse)(nil), // 19: comunicacion.ServerStatusUpdateResponse
+	(*AdminRequest)(nil),               // 20: comunicacion.AdminRequest
+	(*ServerState)(nil),                // 21: comunicacion.ServerState
+	(*PlayerQueueEntry)(nil),           // 22: comunicacion.PlayerQueueEntry
+	(*GameModeQueue)(nil),              // 23: comunicacion.GameModeQueue
+	(*SystemStatusResponse)(nil),       // 24: comunicacion.SystemStatusResponse
+	(*PeerConnection)(nil),             // 25: comunicacion.PeerConnection
+	(*AdminServerUpdateRequest)(nil),   // 26: comunicacion.AdminServerUpdateRequest
+	(*AdminUpdateResponse)(nil),        // 27: comunicacion.AdminUpdateResponse
+	(*ServerId)(nil),                   // 28: comunicacion.ServerId
+	(*PingResponse)(nil),               // 29: comunicacion.PingResponse
+	(*LeaseRequest)(nil),               // 30: comunicacion.LeaseRequest
+	(*LeaseResponse)(nil),              // 31: comunicacion.LeaseResponse
+	(*DeregisterRequest)(nil),          // 32: comunicacion.DeregisterRequest
+	(*DeregisterResponse)(nil),         // 33: comunicacion.DeregisterResponse
+	(*VectorClock)(nil),                // 34: comunicacion.VectorClock
+	(*HybridClock)(nil),                // 35: comunicacion.HybridClock
+	(*Jugador)(nil),                    // 36: comunicacion.Jugador
+	(*VoteRequest)(nil),                // 37: comunicacion.VoteRequest
+	(*VoteResponse)(nil),               // 38: comunicacion.VoteResponse
+	(*LogEntry)(nil),                   // 39: comunicacion.LogEntry
+	(*AppendEntriesRequest)(nil),       // 40: comunicacion.AppendEntriesRequest
+	(*AppendEntriesResponse)(nil),      // 41: comunicacion.AppendEntriesResponse
+	(*InstallSnapshotRequest)(nil),     // 42: comunicacion.InstallSnapshotRequest
+	(*InstallSnapshotResponse)(nil),    // 43: comunicacion.InstallSnapshotResponse
+	nil,                                // 44: comunicacion.VectorClock.ClocksEntry
 }
 var file_comunicacion_proto_depIdxs = []int32{
-	16, // 0: comunicacion.PlayerInfoRequest.vector_clock:type_name -> comunicacion.VectorClock
-	16, // 1: comunicacion.QueuePlayerResponse.vector_clock:type_name -> comunicacion.VectorClock
-	16, // 2: comunicacion.PlayerStatusResponse.vector_clock:type_name -> comunicacion.VectorClock
-	16, // 3: comunicacion.AssignMatchRequest.vector_clock:type_name -> comunicacion.VectorClock
-	16, // 4: comunicacion.AssignMatchResponse.vector_clock:type_name -> comunicacion.VectorClock
-	16, // 5: comunicacion.ServerStatusUpdateRequest.vector_clock:type_name -> comunicacion.VectorClock
-	16, // 6: comunicacion.ServerStatusUpdateResponse.vector_clock:type_name -> comunicacion.VectorClock
-	9,  // 7: comunicacion.SystemStatusResponse.servers:type_name -> comunicacion.ServerState
-	10, // 8: comunicacion.SystemStatusResponse.player_queue:type_name -> comunicacion.PlayerQueueEntry
-	16, // 9: comunicacion.SystemStatusResponse.vector_clock:type_name -> comunicacion.VectorClock
-	18, // 10: comunicacion.VectorClock.clocks:type_name -> comunicacion.VectorClock.ClocksEntry
-	0,  // 11: comunicacion.ComunicacionService.QueuePlayer:input_type -> comunicacion.PlayerInfoRequest
-	2,  // 12: comunicacion.ComunicacionService.GetPlayerStatus:input_type -> comunicacion.PlayerStatusRequest
-	4,  // 13: comunicacion.ComunicacionService.AssignMatch:input_type -> comunicacion.AssignMatchRequest
-	6,  // 14: comunicacion.ComunicacionService.UpdateServerStatus:input_type -> comunicacion.ServerStatusUpdateRequest
-	8,  // 15: comunicacion.ComunicacionService.AdminGetSystemStatus:input_type -> comunicacion.AdminRequest
-	12, // 16: comunicacion.ComunicacionService.AdminUpdateServerState:input_type -> comunicacion.AdminServerUpdateRequest
-	14, // 17: comunicacion.ComunicacionService.PingServer:input_type -> comunicacion.ServerId
-	1,  // 18: comunicacion.ComunicacionService.QueuePlayer:output_type -> comunicacion.QueuePlayerResponse
-	3,  // 19: comunicacion.ComunicacionService.GetPlayerStatus:output_type -> comunicacion.PlayerStatusResponse
-	5,  // 20: comunicacion.ComunicacionService.AssignMatch:output_type -> comunicacion.AssignMatchResponse
-	7,  // 21: comunicacion.ComunicacionService.UpdateServerStatus:output_type -> comunicacion.ServerStatusUpdateResponse
-	11, // 22: comunicacion.ComunicacionService.AdminGetSystemStatus:output_type -> comunicacion.SystemStatusResponse
-	13, // 23: comunicacion.ComunicacionService.AdminUpdateServerState:output_type -> comunicacion.AdminUpdateResponse
-	15, // 24: comunicacion.ComunicacionService.PingServer:output_type -> comunicacion.PingResponse
-	18, // [18:25] is the sub-list for method output_type
-	11, // [11:18] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	34, // 0: comunicacion.PlayerInfoRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 1: comunicacion.QueuePlayerResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 2: comunicacion.LeaveQueueRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 3: comunicacion.LeaveQueueResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 4: comunicacion.ReadyCheckRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 5: comunicacion.ReadyCheckResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 6: comunicacion.WaitTimeRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 7: comunicacion.WaitTimeResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 8: comunicacion.PlayerStatusRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 9: comunicacion.PlayerStatusResponse.vector_clock:type_name -> comunicacion.VectorClock
+	14, // 10: comunicacion.PlayerStatusResponse.match:type_name -> comunicacion.MatchInfo
+	34, // 11: comunicacion.PartyRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 12: comunicacion.PartyResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 13: comunicacion.AssignMatchRequest.vector_clock:type_name -> comunicacion.VectorClock
+	13, // 14: comunicacion.AssignMatchRequest.teams:type_name -> comunicacion.Team
+	13, // 15: comunicacion.MatchInfo.teams:type_name -> comunicacion.Team
+	34, // 16: comunicacion.AssignMatchResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 17: comunicacion.MatchResultRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 18: comunicacion.MatchResultResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 19: comunicacion.ServerStatusUpdateRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 20: comunicacion.ServerStatusUpdateResponse.vector_clock:type_name -> comunicacion.VectorClock
+	22, // 21: comunicacion.GameModeQueue.players:type_name -> comunicacion.PlayerQueueEntry
+	21, // 22: comunicacion.SystemStatusResponse.servers:type_name -> comunicacion.ServerState
+	22, // 23: comunicacion.SystemStatusResponse.player_queue:type_name -> comunicacion.PlayerQueueEntry
+	34, // 24: comunicacion.SystemStatusResponse.vector_clock:type_name -> comunicacion.VectorClock
+	23, // 25: comunicacion.SystemStatusResponse.game_mode_queues:type_name -> comunicacion.GameModeQueue
+	14, // 26: comunicacion.SystemStatusResponse.matches:type_name -> comunicacion.MatchInfo
+	25, // 27: comunicacion.SystemStatusResponse.connections:type_name -> comunicacion.PeerConnection
+	34, // 28: comunicacion.ServerId.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 29: comunicacion.PingResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 30: comunicacion.LeaseRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 31: comunicacion.LeaseResponse.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 32: comunicacion.DeregisterRequest.vector_clock:type_name -> comunicacion.VectorClock
+	34, // 33: comunicacion.DeregisterResponse.vector_clock:type_name -> comunicacion.VectorClock
+	44, // 34: comunicacion.VectorClock.clocks:type_name -> comunicacion.VectorClock.ClocksEntry
+	35, // 35: comunicacion.VectorClock.hybrid_clock:type_name -> comunicacion.HybridClock
+	39, // 36: comunicacion.AppendEntriesRequest.entries:type_name -> comunicacion.LogEntry
+	0,  // 37: comunicacion.ComunicacionService.QueuePlayer:input_type -> comunicacion.PlayerInfoRequest
+	8,  // 38: comunicacion.ComunicacionService.GetPlayerStatus:input_type -> comunicacion.PlayerStatusRequest
+	2,  // 39: comunicacion.ComunicacionService.LeaveQueue:input_type -> comunicacion.LeaveQueueRequest
+	4,  // 40: comunicacion.ComunicacionService.RespondReadyCheck:input_type -> comunicacion.ReadyCheckRequest
+	6,  // 41: comunicacion.ComunicacionService.EstimateWaitTime:input_type -> comunicacion.WaitTimeRequest
+	10, // 42: comunicacion.ComunicacionService.CreateParty:input_type -> comunicacion.PartyRequest
+	10, // 43: comunicacion.ComunicacionService.JoinParty:input_type -> comunicacion.PartyRequest
+	10, // 44: comunicacion.ComunicacionService.LeaveParty:input_type -> comunicacion.PartyRequest
+	12, // 45: comunicacion.ComunicacionService.AssignMatch:input_type -> comunicacion.AssignMatchRequest
+	16, // 46: comunicacion.ComunicacionService.ReportMatchResult:input_type -> comunicacion.MatchResultRequest
+	18, // 47: comunicacion.ComunicacionService.UpdateServerStatus:input_type -> comunicacion.ServerStatusUpdateRequest
+	20, // 48: comunicacion.ComunicacionService.AdminGetSystemStatus:input_type -> comunicacion.AdminRequest
+	26, // 49: comunicacion.ComunicacionService.AdminUpdateServerState:input_type -> comunicacion.AdminServerUpdateRequest
+	28, // 50: comunicacion.ComunicacionService.PingServer:input_type -> comunicacion.ServerId
+	30, // 51: comunicacion.ComunicacionService.RenewLease:input_type -> comunicacion.LeaseRequest
+	32, // 52: comunicacion.ComunicacionService.DeregisterServer:input_type -> comunicacion.DeregisterRequest
+	37, // 53: comunicacion.ComunicacionService.RequestVote:input_type -> comunicacion.VoteRequest
+	40, // 54: comunicacion.ComunicacionService.AppendEntries:input_type -> comunicacion.AppendEntriesRequest
+	42, // 55: comunicacion.ComunicacionService.InstallSnapshot:input_type -> comunicacion.InstallSnapshotRequest
+	1,  // 56: comunicacion.ComunicacionService.QueuePlayer:output_type -> comunicacion.QueuePlayerResponse
+	9,  // 57: comunicacion.ComunicacionService.GetPlayerStatus:output_type -> comunicacion.PlayerStatusResponse
+	3,  // 58: comunicacion.ComunicacionService.LeaveQueue:output_type -> comunicacion.LeaveQueueResponse
+	5,  // 59: comunicacion.ComunicacionService.RespondReadyCheck:output_type -> comunicacion.ReadyCheckResponse
+	7,  // 60: comunicacion.ComunicacionService.EstimateWaitTime:output_type -> comunicacion.WaitTimeResponse
+	11, // 61: comunicacion.ComunicacionService.CreateParty:output_type -> comunicacion.PartyResponse
+	11, // 62: comunicacion.ComunicacionService.JoinParty:output_type -> comunicacion.PartyResponse
+	11, // 63: comunicacion.ComunicacionService.LeaveParty:output_type -> comunicacion.PartyResponse
+	15, // 64: comunicacion.ComunicacionService.AssignMatch:output_type -> comunicacion.AssignMatchResponse
+	17, // 65: comunicacion.ComunicacionService.ReportMatchResult:output_type -> comunicacion.MatchResultResponse
+	19, // 66: comunicacion.ComunicacionService.UpdateServerStatus:output_type -> comunicacion.ServerStatusUpdateResponse
+	24, // 67: comunicacion.ComunicacionService.AdminGetSystemStatus:output_type -> comunicacion.SystemStatusResponse
+	27, // 68: comunicacion.ComunicacionService.AdminUpdateServerState:output_type -> comunicacion.AdminUpdateResponse
+	29, // 69: comunicacion.ComunicacionService.PingServer:output_type -> comunicacion.PingResponse
+	31, // 70: comunicacion.ComunicacionService.RenewLease:output_type -> comunicacion.LeaseResponse
+	33, // 71: comunicacion.ComunicacionService.DeregisterServer:output_type -> comunicacion.DeregisterResponse
+	38, // 72: comunicacion.ComunicacionService.RequestVote:output_type -> comunicacion.VoteResponse
+	41, // 73: comunicacion.ComunicacionService.AppendEntries:output_type -> comunicacion.AppendEntriesResponse
+	43, // 74: comunicacion.ComunicacionService.InstallSnapshot:output_type -> comunicacion.InstallSnapshotResponse
+	56, // [56:75] is the sub-list for method output_type
+	37, // [37:56] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_comunicacion_proto_init() }
@@ -1211,7 +3354,7 @@ func file_comunicacion_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_comunicacion_proto_rawDesc), len(file_comunicacion_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
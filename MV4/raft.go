@@ -0,0 +1,730 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+)
+
+// Parámetros de Raft
+const (
+	latidoIntervalo     = 100 * time.Millisecond // cada cuánto el líder envía AppendEntries
+	eleccionTimeoutMin  = 500 * time.Millisecond // sin noticias del líder por este rango se inicia una elección
+	eleccionTimeoutMax  = 1000 * time.Millisecond
+	raftRPCTimeout      = 300 * time.Millisecond
+	maxEntradasPorEnvio = 100
+	commitTimeout       = 5 * time.Second // espera máxima para confirmar una operación
+)
+
+type rolRaft int
+
+const (
+	seguidor rolRaft = iota
+	candidato
+	lider
+)
+
+func (r rolRaft) String() string {
+	switch r {
+	case candidato:
+		return "CANDIDATO"
+	case lider:
+		return "LIDER"
+	}
+	return "SEGUIDOR"
+}
+
+// Entrada del log replicado: un registro de cambios del estado del
+// Matchmaker (vacío en la entrada con que cada líder inicia su término).
+type entradaRaft struct {
+	Index    int64
+	Term     int64
+	Registro json.RawMessage `json:",omitempty"`
+}
+
+// Réplica de Raft. Mantiene el log y la imagen del estado con las entradas
+// confirmadas aplicadas; el Matchmaker solo atiende clientes en el líder.
+type nodoRaft struct {
+	mu      sync.Mutex
+	cambios *sync.Cond // avisa avances del commit y cambios de rol
+
+	id         string
+	peers      map[string]string // ID -> dirección de cada réplica, incluida esta
+	otras      []string          // IDs de las demás réplicas
+	almacen    *almacen
+	conexiones *conexiones
+
+	// Estado persistente
+	term      int64
+	votedFor  string
+	log       []entradaRaft // entradas posteriores al snapshot
+	snapIndex int64
+	snapTerm  int64
+
+	// Estado volátil
+	rol           rolRaft
+	liderID       string
+	imagen        imagenEstado // estado con las entradas hasta lastApplied aplicadas
+	commitIndex   int64
+	lastApplied   int64
+	indiceInicial int64 // entrada inicial del término del líder: al aplicarla empieza a atender
+
+	// Estado del líder
+	nextIndex    map[string]int64
+	matchIndex   map[string]int64
+	enviando     map[string]bool // replicación en curso hacia cada réplica
+	ultimoLatido time.Time
+
+	ultimoContacto  time.Time
+	timeoutEleccion time.Duration
+
+	detenido bool // la réplica se apagó: ya no participa ni escribe a disco
+
+	// Avisos al Matchmaker. Se llaman en una goroutine aparte, sin n.mu tomado
+	alSerLider   func(term int64, img imagenEstado)
+	alDejarLider func()
+}
+
+func nuevoNodoRaft(id string, peers map[string]string, alm *almacen, conns *conexiones, snap *snapshotRaft, estado estadoRaft, entradas []entradaRaft) *nodoRaft {
+	n := &nodoRaft{
+		id:          id,
+		peers:       peers,
+		almacen:     alm,
+		conexiones:  conns,
+		term:        estado.Term,
+		votedFor:    estado.VotedFor,
+		log:         entradas,
+		snapIndex:   snap.LastIndex,
+		snapTerm:    snap.LastTerm,
+		imagen:      snap.Imagen,
+		commitIndex: snap.LastIndex,
+		lastApplied: snap.LastIndex,
+		nextIndex:   make(map[string]int64),
+		matchIndex:  make(map[string]int64),
+		enviando:    make(map[string]bool),
+	}
+	n.cambios = sync.NewCond(&n.mu)
+	for peer := range peers {
+		if peer != id {
+			n.otras = append(n.otras, peer)
+		}
+	}
+	return n
+}
+
+// Arranca los temporizadores de elección y de latidos.
+func (n *nodoRaft) iniciar() {
+	n.mu.Lock()
+	n.reiniciarTimeout()
+	n.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(latidoIntervalo / 5)
+		defer ticker.Stop()
+		for range ticker.C {
+			n.mu.Lock()
+			if n.detenido {
+				n.mu.Unlock()
+				return
+			}
+			switch {
+			case n.rol == lider && time.Since(n.ultimoLatido) >= latidoIntervalo:
+				n.ultimoLatido = time.Now()
+				n.replicarATodos()
+			case n.rol != lider && time.Since(n.ultimoContacto) >= n.timeoutEleccion:
+				go n.iniciarEleccion()
+				n.reiniciarTimeout()
+			}
+			n.mu.Unlock()
+		}
+	}()
+}
+
+// ===================== RPCS =========================
+
+// Las RPCs de Raft no pasan por el bucle de eventos del Matchmaker: el nodo
+// tiene su propia sincronización.
+func (v *servicio) RequestVote(ctx context.Context, req *pb.VoteRequest) (*pb.VoteResponse, error) {
+	return v.s.raft.requestVote(req), nil
+}
+
+func (v *servicio) AppendEntries(ctx context.Context, req *pb.AppendEntriesRequest) (*pb.AppendEntriesResponse, error) {
+	return v.s.raft.appendEntries(req), nil
+}
+
+func (v *servicio) InstallSnapshot(ctx context.Context, req *pb.InstallSnapshotRequest) (*pb.InstallSnapshotResponse, error) {
+	return v.s.raft.installSnapshot(req), nil
+}
+
+func (n *nodoRaft) requestVote(req *pb.VoteRequest) *pb.VoteResponse {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if req.Term > n.term {
+		n.pasarASeguidor(req.Term)
+	}
+	res := &pb.VoteResponse{Term: n.term}
+	if req.Term < n.term {
+		return res
+	}
+
+	// Solo se vota por candidatos con el log al menos tan actualizado como
+	// el propio, para que el líder tenga todas las entradas confirmadas
+	ultimo := n.ultimoIndice()
+	ultimoTerm := n.terminoEn(ultimo)
+	actualizado := req.LastLogTerm > ultimoTerm || (req.LastLogTerm == ultimoTerm && req.LastLogIndex >= ultimo)
+	if (n.votedFor == "" || n.votedFor == req.CandidateId) && actualizado {
+		n.votedFor = req.CandidateId
+		n.persistirEstado()
+		n.reiniciarTimeout()
+		res.VoteGranted = true
+	}
+	return res
+}
+
+func (n *nodoRaft) appendEntries(req *pb.AppendEntriesRequest) *pb.AppendEntriesResponse {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	res := &pb.AppendEntriesResponse{Term: n.term, LastLogIndex: n.ultimoIndice()}
+	if req.Term < n.term {
+		return res
+	}
+	if req.Term > n.term || n.rol != seguidor {
+		n.pasarASeguidor(req.Term)
+	}
+	res.Term = n.term
+	n.liderID = req.LeaderId
+	n.reiniciarTimeout()
+
+	// El log debe contener la entrada anterior a las enviadas
+	if req.PrevLogIndex > n.ultimoIndice() {
+		return res
+	}
+	if req.PrevLogIndex >= n.snapIndex && n.terminoEn(req.PrevLogIndex) != req.PrevLogTerm {
+		res.LastLogIndex = req.PrevLogIndex - 1
+		if res.LastLogIndex < n.snapIndex {
+			res.LastLogIndex = n.snapIndex
+		}
+		return res
+	}
+
+	var nuevas []entradaRaft
+	for _, e := range req.Entries {
+		if e.Index <= n.snapIndex {
+			continue
+		}
+		if len(nuevas) == 0 && e.Index <= n.ultimoIndice() {
+			if n.terminoEn(e.Index) == e.Term {
+				continue
+			}
+			// Entradas en conflicto con el líder: se descartan desde aquí
+			n.truncarDesde(e.Index)
+		}
+		nuevas = append(nuevas, entradaRaft{Index: e.Index, Term: e.Term, Registro: e.Data})
+	}
+	n.agregarEntradas(nuevas...)
+
+	// Solo se confirma hasta la última entrada que este mensaje garantiza que
+	// coincide con el líder. Un mensaje atrasado (con PrevLogIndex menor que
+	// lo ya confirmado) no puede hacer retroceder commitIndex
+	if ultimaNueva := req.PrevLogIndex + int64(len(req.Entries)); req.LeaderCommit > n.commitIndex {
+		n.commitIndex = max(n.commitIndex, min(req.LeaderCommit, ultimaNueva))
+		n.aplicarConfirmadas()
+	}
+
+	res.Success = true
+	res.LastLogIndex = n.ultimoIndice()
+	return res
+}
+
+func (n *nodoRaft) installSnapshot(req *pb.InstallSnapshotRequest) *pb.InstallSnapshotResponse {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	res := &pb.InstallSnapshotResponse{Term: n.term}
+	if req.Term < n.term {
+		return res
+	}
+	if req.Term > n.term || n.rol != seguidor {
+		n.pasarASeguidor(req.Term)
+	}
+	res.Term = n.term
+	n.liderID = req.LeaderId
+	n.reiniciarTimeout()
+
+	if req.LastIncludedIndex <= n.lastApplied {
+		return res
+	}
+	var img imagenEstado
+	if err := json.Unmarshal(req.Data, &img); err != nil {
+		log.Printf("[Raft %s] Snapshot inválido de %s: %v", n.id, req.LeaderId, err)
+		return res
+	}
+
+	// Si el log ya tiene la última entrada del snapshot se conservan las
+	// posteriores; si no, se descarta completo
+	if n.terminoEn(req.LastIncludedIndex) == req.LastIncludedTerm {
+		n.log = append([]entradaRaft(nil), n.log[req.LastIncludedIndex-n.snapIndex:]...)
+	} else {
+		n.log = nil
+	}
+	n.snapIndex, n.snapTerm = req.LastIncludedIndex, req.LastIncludedTerm
+	n.imagen = img
+	n.lastApplied = req.LastIncludedIndex
+	n.commitIndex = max(n.commitIndex, req.LastIncludedIndex)
+	n.guardarSnapshot()
+	n.aplicarConfirmadas()
+
+	log.Printf("[Raft %s] Snapshot instalado hasta el índice %d", n.id, n.snapIndex)
+	return res
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Agrega un registro de cambios al log y devuelve su índice. Solo el líder
+// puede proponer. Se llama desde el bucle de eventos del Matchmaker, para que
+// los registros se propongan en el mismo orden en que se hicieron los cambios.
+func (n *nodoRaft) proponer(registro []byte) (int64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.rol != lider {
+		return 0, fmt.Errorf("la réplica %s ya no es líder", n.id)
+	}
+	indice := n.ultimoIndice() + 1
+	n.agregarEntradas(entradaRaft{Index: indice, Term: n.term, Registro: registro})
+	n.avanzarCommit()
+	n.replicarATodos()
+	return indice, nil
+}
+
+// Índice de la última entrada del log.
+func (n *nodoRaft) ultimaEntrada() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ultimoIndice()
+}
+
+// Espera a que se confirme la entrada del índice indicado (y con ella todas
+// las anteriores). Falla si la réplica deja de ser líder o se agota el tiempo.
+func (n *nodoRaft) esperarCommit(indice int64) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	term := n.term
+	limite := time.Now().Add(commitTimeout)
+	despertar := time.AfterFunc(commitTimeout, func() {
+		n.mu.Lock()
+		n.cambios.Broadcast()
+		n.mu.Unlock()
+	})
+	defer despertar.Stop()
+
+	for n.commitIndex < indice {
+		if n.rol != lider || n.term != term {
+			return fmt.Errorf("la réplica %s dejó de ser líder", n.id)
+		}
+		if time.Now().After(limite) {
+			return fmt.Errorf("tiempo agotado esperando la confirmación de la mayoría")
+		}
+		n.cambios.Wait()
+	}
+	return nil
+}
+
+// Devuelve el ID y la dirección del líder conocido.
+func (n *nodoRaft) lider() (string, string, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.liderID == "" {
+		return "", "", false
+	}
+	return n.liderID, n.peers[n.liderID], true
+}
+
+// Indica si la réplica es líder en el término indicado.
+func (n *nodoRaft) esLiderEn(term int64) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.rol == lider && n.term == term
+}
+
+func (n *nodoRaft) esLider() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.rol == lider
+}
+
+func (n *nodoRaft) iniciarEleccion() {
+	n.mu.Lock()
+	if n.rol == lider {
+		n.mu.Unlock()
+		return
+	}
+	n.term++
+	n.rol = candidato
+	n.votedFor = n.id
+	n.liderID = ""
+	n.persistirEstado()
+	term := n.term
+	ultimo := n.ultimoIndice()
+	req := &pb.VoteRequest{
+		Term:         term,
+		CandidateId:  n.id,
+		LastLogIndex: ultimo,
+		LastLogTerm:  n.terminoEn(ultimo),
+	}
+	log.Printf("[Raft %s] Iniciando elección para el término %d", n.id, term)
+
+	votos := 1
+	if votos >= n.mayoria() {
+		n.convertirEnLider()
+		n.mu.Unlock()
+		return
+	}
+	n.mu.Unlock()
+
+	for _, peer := range n.otras {
+		go func(peer string) {
+			cliente, err := n.cliente(peer)
+			if err != nil {
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), raftRPCTimeout)
+			defer cancel()
+			res, err := cliente.RequestVote(ctx, req)
+			if err != nil {
+				return
+			}
+
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			if res.Term > n.term {
+				n.pasarASeguidor(res.Term)
+				return
+			}
+			if n.rol != candidato || n.term != term || !res.VoteGranted {
+				return
+			}
+			votos++
+			if votos >= n.mayoria() {
+				n.convertirEnLider()
+			}
+		}(peer)
+	}
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) convertirEnLider() {
+	n.rol = lider
+	n.liderID = n.id
+	for _, peer := range n.otras {
+		n.nextIndex[peer] = n.ultimoIndice() + 1
+		n.matchIndex[peer] = 0
+	}
+	log.Printf("[Raft %s] Elegido líder para el término %d", n.id, n.term)
+
+	// La entrada inicial del término confirma de paso todas las anteriores.
+	// Cuando se aplica, el Matchmaker restaura su estado y empieza a atender
+	n.indiceInicial = n.ultimoIndice() + 1
+	n.agregarEntradas(entradaRaft{Index: n.indiceInicial, Term: n.term})
+	n.avanzarCommit()
+	n.ultimoLatido = time.Now()
+	n.replicarATodos()
+	n.cambios.Broadcast()
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) pasarASeguidor(term int64) {
+	if term > n.term {
+		n.term = term
+		n.votedFor = ""
+		n.persistirEstado()
+	}
+	if n.rol == lider {
+		log.Printf("[Raft %s] Deja de ser líder (término %d)", n.id, n.term)
+		go n.alDejarLider()
+	}
+	n.rol = seguidor
+	n.cambios.Broadcast()
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) replicarATodos() {
+	for _, peer := range n.otras {
+		if !n.enviando[peer] {
+			n.enviando[peer] = true
+			go n.replicar(peer)
+		}
+	}
+}
+
+// Envía a una réplica las entradas que le faltan (o un snapshot, si ya no
+// están en el log) hasta dejarla al día.
+func (n *nodoRaft) replicar(peer string) {
+	defer func() {
+		n.mu.Lock()
+		n.enviando[peer] = false
+		n.mu.Unlock()
+	}()
+	cliente, err := n.cliente(peer)
+	if err != nil {
+		return
+	}
+
+	for {
+		n.mu.Lock()
+		if n.rol != lider {
+			n.mu.Unlock()
+			return
+		}
+		term := n.term
+		next := n.nextIndex[peer]
+
+		if next <= n.snapIndex {
+			data, err := json.Marshal(n.imagen)
+			if err != nil {
+				log.Fatalf("[Raft %s] Error al serializar el snapshot: %v", n.id, err)
+			}
+			req := &pb.InstallSnapshotRequest{
+				Term:              term,
+				LeaderId:          n.id,
+				LeaderAddress:     n.peers[n.id],
+				LastIncludedIndex: n.lastApplied,
+				LastIncludedTerm:  n.terminoEn(n.lastApplied),
+				Data:              data,
+			}
+			n.mu.Unlock()
+
+			ctx, cancel := context.WithTimeout(context.Background(), raftRPCTimeout*10)
+			res, err := cliente.InstallSnapshot(ctx, req)
+			cancel()
+
+			n.mu.Lock()
+			if err != nil || !n.respuestaVigente(res.GetTerm(), term) {
+				n.mu.Unlock()
+				return
+			}
+			n.matchIndex[peer] = max(n.matchIndex[peer], req.LastIncludedIndex)
+			n.nextIndex[peer] = n.matchIndex[peer] + 1
+			n.mu.Unlock()
+			continue
+		}
+
+		prev := next - 1
+		desde := next - n.snapIndex - 1
+		hasta := min(int64(len(n.log)), desde+maxEntradasPorEnvio)
+		req := &pb.AppendEntriesRequest{
+			Term:          term,
+			LeaderId:      n.id,
+			LeaderAddress: n.peers[n.id],
+			PrevLogIndex:  prev,
+			PrevLogTerm:   n.terminoEn(prev),
+			LeaderCommit:  n.commitIndex,
+		}
+		for _, e := range n.log[desde:hasta] {
+			req.Entries = append(req.Entries, &pb.LogEntry{Index: e.Index, Term: e.Term, Data: e.Registro})
+		}
+		n.mu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), raftRPCTimeout)
+		res, err := cliente.AppendEntries(ctx, req)
+		cancel()
+
+		n.mu.Lock()
+		if err != nil || !n.respuestaVigente(res.GetTerm(), term) {
+			n.mu.Unlock()
+			return
+		}
+		if !res.Success {
+			// Retrocede hasta encontrar la última entrada en común
+			n.nextIndex[peer] = max(1, min(prev, res.LastLogIndex+1))
+			n.mu.Unlock()
+			continue
+		}
+		n.matchIndex[peer] = max(n.matchIndex[peer], prev+int64(len(req.Entries)))
+		n.nextIndex[peer] = n.matchIndex[peer] + 1
+		n.avanzarCommit()
+		alDia := n.nextIndex[peer] > n.ultimoIndice()
+		n.mu.Unlock()
+		if alDia {
+			return
+		}
+	}
+}
+
+// Indica si la respuesta de una réplica sigue siendo útil: si trae un término
+// mayor, esta réplica deja de ser líder.
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) respuestaVigente(termRespuesta, termEnvio int64) bool {
+	if termRespuesta > n.term {
+		n.pasarASeguidor(termRespuesta)
+		return false
+	}
+	return n.rol == lider && n.term == termEnvio
+}
+
+// Confirma la entrada más reciente del término actual que ya está en la
+// mayoría de las réplicas.
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) avanzarCommit() {
+	for idx := n.ultimoIndice(); idx > n.commitIndex; idx-- {
+		if n.terminoEn(idx) != n.term {
+			return
+		}
+		copias := 1
+		for _, peer := range n.otras {
+			if n.matchIndex[peer] >= idx {
+				copias++
+			}
+		}
+		if copias >= n.mayoria() {
+			n.commitIndex = idx
+			n.aplicarConfirmadas()
+			return
+		}
+	}
+}
+
+// Aplica sobre la imagen las entradas confirmadas y todavía no aplicadas.
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) aplicarConfirmadas() {
+	for n.lastApplied < n.commitIndex {
+		n.lastApplied++
+		e := n.log[n.lastApplied-n.snapIndex-1]
+		if len(e.Registro) > 0 {
+			var reg registroWAL
+			if err := json.Unmarshal(e.Registro, &reg); err != nil {
+				log.Fatalf("[Raft %s] Entrada %d inválida: %v", n.id, e.Index, err)
+			}
+			n.imagen.aplicar(reg)
+		}
+		if n.rol == lider && e.Index == n.indiceInicial {
+			go n.alSerLider(n.term, n.imagen.copiar())
+		}
+	}
+	n.cambios.Broadcast()
+
+	if n.lastApplied-n.snapIndex >= snapshotCada {
+		n.snapTerm = n.terminoEn(n.lastApplied)
+		n.log = append([]entradaRaft(nil), n.log[n.lastApplied-n.snapIndex:]...)
+		n.snapIndex = n.lastApplied
+		n.guardarSnapshot()
+	}
+}
+
+// Detiene la réplica al apagar el Matchmaker: deja de hacer elecciones y de
+// replicar, guarda un snapshot con todo lo aplicado y cierra el WAL.
+func (n *nodoRaft) detener() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.detenido {
+		return
+	}
+
+	if n.lastApplied > n.snapIndex {
+		n.snapTerm = n.terminoEn(n.lastApplied)
+		n.log = append([]entradaRaft(nil), n.log[n.lastApplied-n.snapIndex:]...)
+		n.snapIndex = n.lastApplied
+	}
+	n.guardarSnapshot()
+	n.detenido = true
+	if err := n.almacen.cerrar(); err != nil {
+		log.Printf("[Raft %s] Error al cerrar el WAL: %v", n.id, err)
+	}
+	log.Printf("[Raft %s] Réplica detenida. Snapshot guardado hasta el índice %d", n.id, n.snapIndex)
+}
+
+// Las respuestas de réplicas que llegan después de detener la réplica ya no
+// se escriben a disco: el snapshot final tiene todo lo aplicado y el resto se
+// recupera del cluster.
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) guardarSnapshot() {
+	if n.detenido {
+		return
+	}
+	snap := &snapshotRaft{LastIndex: n.snapIndex, LastTerm: n.snapTerm, Imagen: n.imagen}
+	if err := n.almacen.compactar(snap, estadoRaft{Term: n.term, VotedFor: n.votedFor}, n.log); err != nil {
+		log.Fatalf("[Raft %s] Error al escribir el snapshot: %v", n.id, err)
+	}
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) agregarEntradas(entradas ...entradaRaft) {
+	if len(entradas) == 0 || n.detenido {
+		return
+	}
+	regs := make([]registroRaft, len(entradas))
+	for i := range entradas {
+		regs[i] = registroRaft{Entrada: &entradas[i]}
+	}
+	if err := n.almacen.agregar(regs...); err != nil {
+		log.Fatalf("[Raft %s] Error al escribir el WAL: %v", n.id, err)
+	}
+	n.log = append(n.log, entradas...)
+}
+
+// Descarta las entradas desde el índice indicado (nunca confirmadas).
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) truncarDesde(indice int64) {
+	if n.detenido {
+		return
+	}
+	if err := n.almacen.agregar(registroRaft{TruncarDesde: indice}); err != nil {
+		log.Fatalf("[Raft %s] Error al escribir el WAL: %v", n.id, err)
+	}
+	n.log = n.log[:indice-n.snapIndex-1]
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) persistirEstado() {
+	if n.detenido {
+		return
+	}
+	if err := n.almacen.agregar(registroRaft{Estado: &estadoRaft{Term: n.term, VotedFor: n.votedFor}}); err != nil {
+		log.Fatalf("[Raft %s] Error al escribir el WAL: %v", n.id, err)
+	}
+}
+
+// Cliente para otra réplica, sobre la conexión compartida con ella.
+func (n *nodoRaft) cliente(peer string) (pb.ComunicacionServiceClient, error) {
+	return n.conexiones.cliente("Réplica "+peer, n.peers[peer])
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) reiniciarTimeout() {
+	n.ultimoContacto = time.Now()
+	n.timeoutEleccion = eleccionTimeoutMin + time.Duration(rand.Int63n(int64(eleccionTimeoutMax-eleccionTimeoutMin)))
+}
+
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) ultimoIndice() int64 {
+	if len(n.log) > 0 {
+		return n.log[len(n.log)-1].Index
+	}
+	return n.snapIndex
+}
+
+// Término de la entrada en el índice indicado, o -1 si no está en el log.
+// Debe llamarse con n.mu tomado.
+func (n *nodoRaft) terminoEn(indice int64) int64 {
+	if indice == n.snapIndex {
+		return n.snapTerm
+	}
+	pos := indice - n.snapIndex - 1
+	if pos < 0 || pos >= int64(len(n.log)) {
+		return -1
+	}
+	return n.log[pos].Term
+}
+
+func (n *nodoRaft) mayoria() int {
+	return len(n.peers)/2 + 1
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Tiempo máximo que esperan las pruebas a que el cluster llegue a un estado.
+const esperaPrueba = 10 * time.Second
+
+func init() {
+	// En todas las pruebas, un cambio del estado sin marcar detiene el
+	// Matchmaker (ver comprobarMarcas)
+	comprobarCambios = true
+}
+
+// ===== CLUSTER DE PRUEBA =====
+
+// Cluster de réplicas del Matchmaker en el mismo proceso, cada una con su
+// servidor gRPC en un puerto libre de localhost y su directorio de datos.
+type clusterPrueba struct {
+	t        *testing.T
+	peers    map[string]string
+	dir      string
+	replicas map[string]*replicaPrueba
+}
+
+type replicaPrueba struct {
+	lis      net.Listener
+	srv      *server
+	g        *grpc.Server
+	activa   bool
+	conexion *grpc.ClientConn
+}
+
+// Crea un cluster de n réplicas (IDs "1".."n") sin arrancarlas. Las réplicas
+// que sigan activas se apagan al terminar la prueba.
+func nuevoClusterPrueba(t *testing.T, n int) *clusterPrueba {
+	t.Helper()
+	c := &clusterPrueba{t: t, peers: make(map[string]string), dir: t.TempDir(), replicas: make(map[string]*replicaPrueba)}
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprint(i)
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Error al escuchar: %v", err)
+		}
+		c.peers[id] = lis.Addr().String()
+		c.replicas[id] = &replicaPrueba{lis: lis}
+	}
+	t.Cleanup(func() {
+		for id, r := range c.replicas {
+			if r.activa {
+				c.detener(id)
+			} else if r.srv == nil {
+				r.lis.Close()
+			}
+			if r.conexion != nil {
+				r.conexion.Close()
+			}
+		}
+	})
+	return c
+}
+
+// Arranca una réplica con su directorio de datos.
+func (c *clusterPrueba) arrancar(id string) *server {
+	c.t.Helper()
+	selector, err := nuevoSelector(seleccionRoundRobin, 1)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	r := c.replicas[id]
+	r.srv, r.g, err = nuevaReplica(id, c.peers, filepath.Join(c.dir, id), selector, nil)
+	if err != nil {
+		c.t.Fatalf("Error al crear la réplica %s: %v", id, err)
+	}
+	r.srv.iniciar()
+	go r.g.Serve(r.lis)
+	r.activa = true
+	return r.srv
+}
+
+// Apaga una réplica como con SIGTERM.
+func (c *clusterPrueba) detener(id string) {
+	r := c.replicas[id]
+	r.srv.apagar(r.g)
+	r.activa = false
+}
+
+// Cliente gRPC hacia una réplica.
+func (c *clusterPrueba) cliente(id string) pb.ComunicacionServiceClient {
+	c.t.Helper()
+	r := c.replicas[id]
+	if r.conexion == nil {
+		conn, err := grpc.NewClient(c.peers[id], grpc.WithInsecure())
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		r.conexion = conn
+	}
+	return pb.NewComunicacionServiceClient(r.conexion)
+}
+
+// Espera a que una de las réplicas activas sea líder y esté atendiendo, y
+// devuelve su ID y término. Falla si en algún momento hay dos líderes en el
+// mismo término.
+func (c *clusterPrueba) esperarLider() (string, int64) {
+	c.t.Helper()
+	limite := time.Now().Add(esperaPrueba)
+	for time.Now().Before(limite) {
+		lideres := make(map[int64]string)
+		for id, r := range c.replicas {
+			if !r.activa {
+				continue
+			}
+			r.srv.raft.mu.Lock()
+			esLider, term := r.srv.raft.rol == lider, r.srv.raft.term
+			r.srv.raft.mu.Unlock()
+			if !esLider {
+				continue
+			}
+			if otro, ok := lideres[term]; ok {
+				c.t.Fatalf("Réplicas %s y %s son líderes en el término %d", otro, id, term)
+			}
+			lideres[term] = id
+			if r.srv.sirviendo.Load() {
+				return id, term
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	c.t.Fatalf("No se eligió líder en %s", esperaPrueba)
+	return "", 0
+}
+
+// Espera a que la réplica aplique la misma imagen que el líder.
+func (c *clusterPrueba) esperarAlDia(id, liderID string) {
+	c.t.Helper()
+	limite := time.Now().Add(esperaPrueba)
+	var img, esperada imagenEstado
+	for time.Now().Before(limite) {
+		esperada, _ = imagenAplicada(c.replicas[liderID].srv.raft)
+		img, _ = imagenAplicada(c.replicas[id].srv.raft)
+		if reflect.DeepEqual(img, esperada) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	c.t.Fatalf("La réplica %s no se puso al día con el líder %s: %d entidades, se esperaban %d", id, liderID, len(img), len(esperada))
+}
+
+// Copia de la imagen aplicada por una réplica y el índice hasta el que está
+// aplicada.
+func imagenAplicada(n *nodoRaft) (imagenEstado, int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.imagen.copiar(), n.lastApplied
+}
+
+func contexto(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), esperaPrueba)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// ===== PRUEBAS =====
+
+func TestEligeUnLiderYLoReemplazaAlCaer(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	for id := range c.peers {
+		c.arrancar(id)
+	}
+
+	primero, term := c.esperarLider()
+	c.detener(primero)
+
+	segundo, nuevoTerm := c.esperarLider()
+	if segundo == primero {
+		t.Fatalf("La réplica %s sigue como líder después de apagarse", primero)
+	}
+	if nuevoTerm <= term {
+		t.Errorf("El nuevo líder %s quedó en el término %d, no posterior a %d", segundo, nuevoTerm, term)
+	}
+}
+
+func TestReplicaLosCambiosEnLosSeguidores(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	for id := range c.peers {
+		c.arrancar(id)
+	}
+	liderID, _ := c.esperarLider()
+
+	ctx := contexto(t)
+	for jugador := int32(1); jugador <= 5; jugador++ {
+		if _, err := c.cliente(liderID).QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: jugador}); err != nil {
+			t.Fatalf("QueuePlayer(%d): %v", jugador, err)
+		}
+	}
+
+	for id := range c.peers {
+		if id == liderID {
+			continue
+		}
+		c.esperarAlDia(id, liderID)
+		img, _ := imagenAplicada(c.replicas[id].srv.raft)
+		for jugador := 1; jugador <= 5; jugador++ {
+			if _, ok := img[fmt.Sprintf("jugador/%d", jugador)]; !ok {
+				t.Errorf("La réplica %s no tiene al jugador %d", id, jugador)
+			}
+		}
+	}
+}
+
+func TestSeguidorAtrasadoSePoneAlDiaConSnapshot(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	c.arrancar("1")
+	c.arrancar("2")
+	liderID, _ := c.esperarLider()
+
+	// Cada llamada agrega una entrada: alcanza para que el líder compacte
+	// su log y ya no tenga las primeras entradas para la réplica 3
+	ctx := contexto(t)
+	cli := c.cliente(liderID)
+	for i := 0; i < snapshotCada/2+10; i++ {
+		if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: 1}); err != nil {
+			t.Fatalf("QueuePlayer: %v", err)
+		}
+		if _, err := cli.LeaveQueue(ctx, &pb.LeaveQueueRequest{PlayerId: 1}); err != nil {
+			t.Fatalf("LeaveQueue: %v", err)
+		}
+	}
+	if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: 2}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+
+	lider := c.replicas[liderID].srv.raft
+	lider.mu.Lock()
+	snapLider := lider.snapIndex
+	lider.mu.Unlock()
+	if snapLider == 0 {
+		t.Fatalf("El líder no compactó su log después de %d entradas", snapshotCada)
+	}
+
+	tercera := c.arrancar("3")
+	c.esperarAlDia("3", liderID)
+
+	tercera.raft.mu.Lock()
+	snapIndex := tercera.raft.snapIndex
+	tercera.raft.mu.Unlock()
+	if snapIndex < snapLider {
+		t.Errorf("La réplica 3 no instaló el snapshot del líder: snapshot hasta %d, el del líder llega a %d", snapIndex, snapLider)
+	}
+	img, _ := imagenAplicada(tercera.raft)
+	if _, ok := img["jugador/2"]; !ok {
+		t.Error("La réplica 3 no tiene las entradas posteriores al snapshot")
+	}
+}
+
+func TestSeguidorRedirigeAlLider(t *testing.T) {
+	c := nuevoClusterPrueba(t, 3)
+	for id := range c.peers {
+		c.arrancar(id)
+	}
+	liderID, _ := c.esperarLider()
+
+	for id := range c.peers {
+		if id == liderID {
+			continue
+		}
+		var trailer metadata.MD
+		_, err := c.cliente(id).AdminGetSystemStatus(contexto(t), &pb.AdminRequest{AdminId: "prueba"}, grpc.Trailer(&trailer))
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("La réplica %s respondió %v, se esperaba FailedPrecondition", id, err)
+		}
+		if got := trailer.Get(claveLider); len(got) != 1 || got[0] != c.peers[liderID] {
+			t.Errorf("La réplica %s indicó el líder %v, se esperaba %s", id, got, c.peers[liderID])
+		}
+	}
+
+	if _, err := c.cliente(liderID).AdminGetSystemStatus(contexto(t), &pb.AdminRequest{AdminId: "prueba"}); err != nil {
+		t.Errorf("El líder rechazó la llamada: %v", err)
+	}
+}
+
+// Un AppendEntries atrasado (con PrevLogIndex menor que lo ya confirmado) no
+// puede hacer retroceder commitIndex por debajo de lo aplicado.
+func TestAppendEntriesAtrasadoNoRetrocedeElCommit(t *testing.T) {
+	alm, snap, estado, entradas, err := abrirAlmacen(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { alm.cerrar() })
+	n := nuevoNodoRaft("2", map[string]string{"1": "", "2": "", "3": ""}, alm, nil, snap, estado, entradas)
+	n.alDejarLider = func() {}
+
+	var nuevas []*pb.LogEntry
+	for i := int64(1); i <= 5; i++ {
+		nuevas = append(nuevas, &pb.LogEntry{Index: i, Term: 1})
+	}
+	res := n.appendEntries(&pb.AppendEntriesRequest{Term: 1, LeaderId: "1", Entries: nuevas, LeaderCommit: 5})
+	if !res.Success || n.commitIndex != 5 {
+		t.Fatalf("Tras replicar 5 entradas: éxito %v, commitIndex %d", res.Success, n.commitIndex)
+	}
+
+	res = n.appendEntries(&pb.AppendEntriesRequest{
+		Term: 1, LeaderId: "1", PrevLogIndex: 2, PrevLogTerm: 1,
+		Entries: nuevas[2:3], LeaderCommit: 6,
+	})
+	if !res.Success {
+		t.Fatal("El AppendEntries atrasado fue rechazado")
+	}
+	if n.commitIndex != 5 || n.lastApplied != 5 {
+		t.Errorf("commitIndex %d y lastApplied %d, se esperaba 5 en ambos", n.commitIndex, n.lastApplied)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Parámetros del sistema de rating (Elo)
+const (
+	ratingInicial = 1500.0 // rating de un jugador nuevo
+	factorK       = 32.0   // variación máxima de rating por partida
+
+	ventanaBase     = 100.0           // diferencia de rating aceptada al entrar a la cola
+	ventanaIncr     = 50.0            // ampliación de la ventana por cada intervalo de espera
+	ventanaMax      = 1000.0          // diferencia máxima aceptada tras esperar mucho
+	ventanaInterval = 5 * time.Second // cada cuánto se amplía la ventana
+)
+
+// Devuelve el rating del jugador, o el inicial si aún no ha jugado.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) ratingDe(playerID int32) float64 {
+	if r, ok := s.playerRating[playerID]; ok {
+		return r
+	}
+	return ratingInicial
+}
+
+// Ventana de habilidad de un jugador según el tiempo que lleva en cola.
+func ventanaHabilidad(espera time.Duration) float64 {
+	v := ventanaBase + ventanaIncr*float64(espera/ventanaInterval)
+	return math.Min(v, ventanaMax)
+}
+
+// Busca en la cola un conjunto de entradas que sume exactamente los jugadores
+// de una partida del modo, dando prioridad a quien lleva más tiempo esperando.
+// Dos entradas son compatibles si la diferencia entre sus ratings promedio cae
+// dentro de la ventana de ambas; el conjunto es válido si todas sus entradas
+// son compatibles entre sí y se pueden repartir en equipos sin separar a
+// ningún grupo. Devuelve los índices en la cola o nil si no hay ninguno.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) buscarGrupo(queue []*queueEntry, cfg modeConfig, ahora time.Time) []int {
+	n := cfg.jugadores()
+	compatible := func(a, b *queueEntry) bool {
+		va := ventanaHabilidad(ahora.Sub(a.EnqueuedAt))
+		vb := ventanaHabilidad(ahora.Sub(b.EnqueuedAt))
+		return math.Abs(s.ratingEquipo(a.Players)-s.ratingEquipo(b.Players)) <= math.Min(va, vb)
+	}
+
+	for i := range queue {
+		grupo := []int{i}
+		seleccion := []*queueEntry{queue[i]}
+		total := len(queue[i].Players)
+		for j := i + 1; j < len(queue) && total < n; j++ {
+			cand := queue[j]
+			if total+len(cand.Players) > n {
+				continue
+			}
+			ok := true
+			for _, e := range seleccion {
+				if !compatible(e, cand) {
+					ok = false
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+			if _, cabe := s.armarEquipos(append(seleccion, cand), cfg); !cabe {
+				continue
+			}
+			grupo = append(grupo, j)
+			seleccion = append(seleccion, cand)
+			total += len(cand.Players)
+		}
+		if total == n {
+			return grupo
+		}
+	}
+	return nil
+}
+
+// Reparte las entradas en equipos equilibrados por rating. Cada grupo va
+// entero a un equipo, salvo en free-for-all donde cada miembro es su propio
+// equipo. Las unidades se ordenan de mayor a menor (por tamaño y luego por
+// rating) y cada una va al equipo con espacio de menor rating acumulado.
+// Devuelve false si las entradas no caben en los equipos del modo.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) armarEquipos(entries []*queueEntry, cfg modeConfig) ([][]int32, bool) {
+	var unidades [][]int32
+	for _, e := range entries {
+		if len(e.Players) <= cfg.TeamSize {
+			unidades = append(unidades, e.Players)
+			continue
+		}
+		for _, id := range e.Players {
+			unidades = append(unidades, []int32{id})
+		}
+	}
+	sort.SliceStable(unidades, func(a, b int) bool {
+		if len(unidades[a]) != len(unidades[b]) {
+			return len(unidades[a]) > len(unidades[b])
+		}
+		return s.ratingEquipo(unidades[a]) > s.ratingEquipo(unidades[b])
+	})
+
+	teams := make([][]int32, cfg.Teams)
+	suma := make([]float64, cfg.Teams)
+	for _, u := range unidades {
+		mejor := -1
+		for t := range teams {
+			if len(teams[t])+len(u) > cfg.TeamSize {
+				continue
+			}
+			if mejor < 0 || suma[t] < suma[mejor] {
+				mejor = t
+			}
+		}
+		if mejor < 0 {
+			return nil, false
+		}
+		teams[mejor] = append(teams[mejor], u...)
+		for _, id := range u {
+			suma[mejor] += s.ratingDe(id)
+		}
+	}
+	return teams, true
+}
+
+// Rating de un equipo: promedio del rating de sus jugadores.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) ratingEquipo(team []int32) float64 {
+	if len(team) == 0 {
+		return ratingInicial
+	}
+	total := 0.0
+	for _, id := range team {
+		total += s.ratingDe(id)
+	}
+	return total / float64(len(team))
+}
+
+// Actualiza el rating de los jugadores de una partida según el equipo ganador
+// (índice base 1, como Team.team_id). Cada equipo se compara contra todos los
+// demás: el ganador vence a cada rival y los perdedores empatan entre sí. Con
+// winningTeam = 0 la partida cuenta como empate para todos.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) actualizarRating(teams [][]int32, winningTeam int32) {
+	if len(teams) < 2 {
+		return
+	}
+
+	ratings := make([]float64, len(teams))
+	for t, team := range teams {
+		ratings[t] = s.ratingEquipo(team)
+	}
+
+	for a, team := range teams {
+		delta := 0.0
+		for b := range teams {
+			if a == b {
+				continue
+			}
+			// Puntaje obtenido por a contra b: 1 si gana, 0 si pierde, 0.5 si empata
+			score := 0.5
+			switch winningTeam {
+			case int32(a + 1):
+				score = 1
+			case int32(b + 1):
+				score = 0
+			}
+			esperado := 1 / (1 + math.Pow(10, (ratings[b]-ratings[a])/400))
+			delta += score - esperado
+		}
+		delta *= factorK / float64(len(teams)-1)
+
+		for _, id := range team {
+			s.playerRating[id] = s.ratingDe(id) + delta
+		}
+		s.marcarJugadores(team...)
+	}
+}
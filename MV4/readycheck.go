@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Tiempo que tienen los jugadores para aceptar una partida encontrada
+const readyCheckTimeout = 15 * time.Second
+
+// Partida encontrada que espera la confirmación de todos sus jugadores antes
+// de asignarse al servidor. El servidor queda reservado mientras tanto.
+type readyCheck struct {
+	MatchID  int32
+	Entries  []*queueEntry // entradas de cola que forman la partida
+	Accepted map[int32]bool
+	Deadline time.Time
+}
+
+// Total de jugadores que deben aceptar la partida.
+func (rc *readyCheck) jugadores() int {
+	total := 0
+	for _, entry := range rc.Entries {
+		total += len(entry.Players)
+	}
+	return total
+}
+
+// ===================== RPCS =========================
+
+func (s *server) RespondReadyCheck(ctx context.Context, req *pb.ReadyCheckRequest) (*pb.ReadyCheckResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("RespondReadyCheck del jugador %d para la partida %d (acepta: %t)", req.PlayerId, req.MatchId, req.Accept), req.VectorClock)
+
+	playerID := req.PlayerId
+	rc, ok := s.readyChecks[req.MatchId]
+	if !ok || s.playerMatch[playerID] != req.MatchId {
+		return &pb.ReadyCheckResponse{
+			StatusCode:  "FAILURE",
+			Message:     "No hay una partida pendiente de confirmación",
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	if !req.Accept {
+		log.Printf("[Matchmaker] Jugador %d rechazó la partida %d", playerID, rc.MatchID)
+		s.fallarReadyCheck(rc, map[int32]bool{playerID: true})
+		return &pb.ReadyCheckResponse{
+			StatusCode:  "SUCCESS",
+			Message:     fmt.Sprintf("Partida %d rechazada. Saliste de la cola", rc.MatchID),
+			VectorClock: s.relojProto(),
+		}, nil
+	}
+
+	rc.Accepted[playerID] = true
+	s.marcarReadyCheck(rc.MatchID)
+	log.Printf("[Matchmaker] Jugador %d aceptó la partida %d (%d/%d)", playerID, rc.MatchID, len(rc.Accepted), rc.jugadores())
+
+	msg := fmt.Sprintf("Partida %d aceptada. Esperando al resto de jugadores (%d/%d)", rc.MatchID, len(rc.Accepted), rc.jugadores())
+	if len(rc.Accepted) == rc.jugadores() {
+		s.confirmarReadyCheck(rc)
+		msg = fmt.Sprintf("Partida %d aceptada por todos. Iniciando partida", rc.MatchID)
+	}
+
+	return &pb.ReadyCheckResponse{
+		StatusCode:  "SUCCESS",
+		Message:     msg,
+		VectorClock: s.relojProto(),
+	}, nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Registra la partida encontrada y programa su vencimiento.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) iniciarReadyCheck(rc *readyCheck) {
+	rc.Accepted = make(map[int32]bool)
+	rc.Deadline = time.Now().Add(readyCheckTimeout)
+	s.readyChecks[rc.MatchID] = rc
+	s.marcarReadyCheck(rc.MatchID)
+
+	for _, entry := range rc.Entries {
+		for _, id := range entry.Players {
+			delete(s.playerMode, id)
+			s.playerStatus[id] = "MATCH FOUND"
+		}
+		s.marcarJugadores(entry.Players...)
+	}
+
+	time.AfterFunc(readyCheckTimeout, func() { s.ejecutar(func() { s.expirarReadyCheck(rc.MatchID) }) })
+}
+
+// Cancela la partida si no todos la aceptaron a tiempo. Quienes no
+// respondieron quedan fuera de la cola.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) expirarReadyCheck(matchID int32) {
+	rc, ok := s.readyChecks[matchID]
+	if !ok || !s.sirviendo.Load() {
+		return
+	}
+
+	sinRespuesta := make(map[int32]bool)
+	var ids []int32
+	for _, entry := range rc.Entries {
+		for _, id := range entry.Players {
+			if !rc.Accepted[id] {
+				sinRespuesta[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	log.Printf("[Matchmaker] Tiempo agotado para aceptar la partida %d. Sin respuesta: %v", matchID, ids)
+	s.registrarEvento(reloj.Local, fmt.Sprintf("Venció la confirmación de la partida %d", matchID), nil)
+	s.fallarReadyCheck(rc, sinRespuesta)
+}
+
+// Todos aceptaron: la partida se asigna al servidor reservado.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) confirmarReadyCheck(rc *readyCheck) {
+	delete(s.readyChecks, rc.MatchID)
+	s.marcarReadyCheck(rc.MatchID)
+
+	match := s.matches[rc.MatchID]
+	match.Status = "EN CURSO"
+	match.StartTime = time.Now()
+	s.marcarPartida(match.ID)
+	s.registrarThroughput(match.Mode, rc.jugadores(), match.StartTime)
+	for _, id := range match.jugadores() {
+		s.playerStatus[id] = "IN MATCH"
+	}
+	s.marcarJugadores(match.jugadores()...)
+
+	gs := s.gameServers[match.ServerID]
+	s.asignadas[gs.ID]++
+	s.marcarServidor(gs.ID)
+	log.Printf("[Matchmaker] Partida %d confirmada. Asignando equipos %v (%s) en %s", match.ID, match.Teams, match.Mode, gs.ID)
+
+	s.enviarAssignMatch(gs, match, rc.Entries, 0)
+}
+
+// La partida no se confirma: los jugadores indicados (y sus grupos) quedan
+// fuera de la cola, el resto vuelve a la cabeza de la cola y el servidor
+// reservado se libera.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) fallarReadyCheck(rc *readyCheck, excluidos map[int32]bool) {
+	delete(s.readyChecks, rc.MatchID)
+	s.marcarReadyCheck(rc.MatchID)
+
+	match := s.matches[rc.MatchID]
+	s.cerrarPartida(match, "CANCELADA")
+	s.devolverACola(match.Mode, rc.Entries, excluidos)
+
+	if gs, ok := s.gameServers[match.ServerID]; ok && gs.Status == "OCUPADO" {
+		gs.Status = "DISPONIBLE"
+		s.marcarServidor(gs.ID)
+	}
+}
@@ -0,0 +1,146 @@
+package reloj
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// Procesos de los relojes aleatorios. Son pocos para que los relojes
+// compartan componentes y salgan todas las relaciones causales.
+var procesosPrueba = []string{"Matchmaker", "Player1", "Player2", "GameServer1"}
+
+// Reloj aleatorio para testing/quick: cada proceso falta, está en 0 (que
+// equivale a faltar) o tiene un valor chico.
+type relojAleatorio Reloj
+
+func (relojAleatorio) Generate(r *rand.Rand, _ int) reflect.Value {
+	vc := make(Reloj)
+	for _, p := range procesosPrueba {
+		switch n := r.Intn(6); n {
+		case 0:
+		case 1:
+			vc[p] = 0
+		default:
+			vc[p] = int32(n - 1)
+		}
+	}
+	return reflect.ValueOf(relojAleatorio(vc))
+}
+
+func verificar(t *testing.T, propiedad interface{}) {
+	t.Helper()
+	if err := quick.Check(propiedad, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func fusion(a, b Reloj) Reloj {
+	f := a.Copia()
+	f.Fusionar(b)
+	return f
+}
+
+func TestCompararEsAntisimetrico(t *testing.T) {
+	verificar(t, func(a, b relojAleatorio) bool {
+		ab, ba := Comparar(Reloj(a), Reloj(b)), Comparar(Reloj(b), Reloj(a))
+		switch ab {
+		case Antes:
+			return ba == Despues
+		case Despues:
+			return ba == Antes
+		}
+		return ba == ab
+	})
+}
+
+func TestCompararConsigoMismoEsIgual(t *testing.T) {
+	verificar(t, func(a relojAleatorio) bool {
+		return Comparar(Reloj(a), Reloj(a)) == Igual && Comparar(Reloj(a), Reloj(a).Copia()) == Igual
+	})
+}
+
+func TestFusionarEsConmutativo(t *testing.T) {
+	verificar(t, func(a, b relojAleatorio) bool {
+		return Comparar(fusion(Reloj(a), Reloj(b)), fusion(Reloj(b), Reloj(a))) == Igual
+	})
+}
+
+func TestFusionarEsAsociativo(t *testing.T) {
+	verificar(t, func(a, b, c relojAleatorio) bool {
+		izq := fusion(fusion(Reloj(a), Reloj(b)), Reloj(c))
+		der := fusion(Reloj(a), fusion(Reloj(b), Reloj(c)))
+		return Comparar(izq, der) == Igual
+	})
+}
+
+func TestFusionarEsIdempotente(t *testing.T) {
+	verificar(t, func(a, b relojAleatorio) bool {
+		f := fusion(Reloj(a), Reloj(b))
+		return Comparar(fusion(Reloj(a), Reloj(a)), Reloj(a)) == Igual && Comparar(fusion(f, Reloj(b)), f) == Igual
+	})
+}
+
+func TestFusionarNoQuedaAntesDeNinguno(t *testing.T) {
+	verificar(t, func(a, b relojAleatorio) bool {
+		f := fusion(Reloj(a), Reloj(b))
+		for _, x := range []Reloj{Reloj(a), Reloj(b)} {
+			if o := Comparar(x, f); o != Antes && o != Igual {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestIncrementarQuedaDespues(t *testing.T) {
+	verificar(t, func(a relojAleatorio, i uint8) bool {
+		p := procesosPrueba[int(i)%len(procesosPrueba)]
+		r := Reloj(a).Copia()
+		r.Incrementar(p)
+		return Comparar(r, Reloj(a)) == Despues && Reloj(a).AntesDe(r)
+	})
+}
+
+func TestCopiaEsIndependiente(t *testing.T) {
+	verificar(t, func(a relojAleatorio) bool {
+		c := Reloj(a).Copia()
+		c.Incrementar("Player1")
+		return Comparar(c, Reloj(a)) == Despues
+	})
+}
+
+// Podar un componente en el que los dos relojes coinciden (por ejemplo, el
+// de un servidor dado de baja que ambos conocen por completo) no cambia su
+// relación. Y podar cualquier componente nunca invierte un orden causal.
+func TestQuitarConservaElOrden(t *testing.T) {
+	verificar(t, func(a, b relojAleatorio, i uint8) bool {
+		p := procesosPrueba[int(i)%len(procesosPrueba)]
+		antes := Comparar(Reloj(a), Reloj(b))
+
+		qa, qb := Reloj(a).Copia(), Reloj(b).Copia()
+		qa.Quitar(p)
+		qb.Quitar(p)
+		despues := Comparar(qa, qb)
+
+		if Reloj(a)[p] == Reloj(b)[p] && despues != antes {
+			return false
+		}
+		switch antes {
+		case Antes:
+			return despues == Antes || despues == Igual
+		case Despues:
+			return despues == Despues || despues == Igual
+		case Igual:
+			return despues == Igual
+		}
+		return true
+	})
+}
+
+func TestConcurrenteConEsSimetrico(t *testing.T) {
+	verificar(t, func(a, b relojAleatorio) bool {
+		return Reloj(a).ConcurrenteCon(Reloj(b)) == Reloj(b).ConcurrenteCon(Reloj(a))
+	})
+}
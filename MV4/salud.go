@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Parámetros de la verificación de salud de los servidores
+const (
+	silencioMax   = 10 * time.Second // sin actualizaciones por más de esto, se hace ping
+	chequeoPeriod = 5 * time.Second  // cada cuánto se revisan los servidores
+	pingTimeout   = 2 * time.Second  // tiempo máximo para responder un ping
+)
+
+// ===================== RPCS =========================
+
+// Verifica en el momento un servidor por pedido del administrador y actualiza
+// su estado con el resultado.
+// El ping se espera fuera del bucle de eventos.
+func (s *server) PingServer(ctx context.Context, req *pb.ServerId) (*pb.PingResponse, error) {
+	var id, addr string
+	s.ejecutar(func() {
+		if gs, ok := s.gameServers[req.ServerId]; ok {
+			id, addr = gs.ID, gs.Address
+		}
+	})
+	if id == "" {
+		return &pb.PingResponse{Status: "DESCONOCIDO", Message: "Servidor no encontrado"}, nil
+	}
+
+	res, err := s.pingServidor(id, addr)
+	var vc *pb.VectorClock
+	s.ejecutar(func() {
+		s.aplicarPing(id, res, err)
+		vc = s.relojProto()
+	})
+
+	if err != nil {
+		return &pb.PingResponse{Status: "CAIDO", Message: fmt.Sprintf("Sin respuesta de %s: %v", id, err), VectorClock: vc}, nil
+	}
+	return &pb.PingResponse{Status: res.Status, Message: res.Message, VectorClock: vc}, nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Hace ping a los servidores que llevan más de silencioMax sin informar su
+// estado. Los que no responden se marcan CAIDO; los que vuelven a responder
+// después de haber sido marcados así quedan DISPONIBLE otra vez. También
+// registra los leases vencidos.
+func (s *server) healthCheckLoop() {
+	ticker := time.NewTicker(chequeoPeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !s.sirviendo.Load() {
+			continue
+		}
+		var silenciosos []*GameServerInfo
+		s.ejecutar(func() {
+			s.revisarLeases(time.Now())
+			s.podarMiembros(time.Now())
+			for _, gs := range s.gameServers {
+				if time.Since(gs.LastUpdate) > silencioMax {
+					silenciosos = append(silenciosos, &GameServerInfo{ID: gs.ID, Address: gs.Address})
+				}
+			}
+		})
+
+		// Los pings se hacen fuera del bucle de eventos para no bloquear al
+		// resto del matchmaker mientras se espera a un servidor colgado
+		for _, gs := range silenciosos {
+			go func(id, addr string) {
+				res, err := s.pingServidor(id, addr)
+				s.ejecutar(func() { s.aplicarPing(id, res, err) })
+			}(gs.ID, gs.Address)
+		}
+	}
+}
+
+// Envía PingServer al servidor de juego indicado. El envío y la respuesta se
+// anotan en el bucle de eventos, como los demás mensajes; el ping se espera
+// fuera de él.
+func (s *server) pingServidor(id, addr string) (*pb.PingResponse, error) {
+	client, err := s.conexiones.cliente(id, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var req *pb.ServerId
+	s.ejecutar(func() {
+		s.registrarEvento(reloj.Envio, "PingServer a "+id, nil)
+		req = &pb.ServerId{ServerId: id, VectorClock: s.relojProto()}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	res, err := client.PingServer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	s.ejecutar(func() {
+		s.registrarEvento(reloj.Recepcion, fmt.Sprintf("%s respondió al ping: %s", id, res.Status), res.VectorClock)
+	})
+	return res, nil
+}
+
+// Actualiza el servidor según el resultado de un ping.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) aplicarPing(id string, res *pb.PingResponse, err error) {
+	gs, ok := s.gameServers[id]
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		if gs.Status != "CAIDO" {
+			log.Printf("[Matchmaker] %s no responde al ping (%v). Marcado como CAIDO", id, err)
+			s.registrarEvento(reloj.Local, id+" no responde al ping", nil)
+			gs.Status = "CAIDO"
+			s.interrumpirPartida(id)
+		}
+		gs.SinRespuesta = true
+		s.marcarServidor(id)
+		return
+	}
+
+	// Solo se recuperan los servidores que se marcaron CAIDO por no
+	// responder; un CAIDO forzado por el administrador se respeta
+	gs.LastUpdate = time.Now()
+	gs.HLC = s.hlc.Actual()
+	s.marcarServidor(id)
+	if gs.SinRespuesta && res.Status == "DISPONIBLE" {
+		log.Printf("[Matchmaker] %s volvió a responder. Marcado como DISPONIBLE", id)
+		s.registrarEvento(reloj.Local, id+" volvió a responder al ping", nil)
+		gs.Status = "DISPONIBLE"
+		gs.SinRespuesta = false
+		s.despertarMatchmaking()
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Estrategias de selección de servidor disponibles (valor del flag -seleccion)
+const (
+	seleccionRoundRobin = "round-robin"
+	seleccionLRU        = "lru"
+	seleccionPonderada  = "ponderada"
+	seleccionAleatoria  = "aleatoria"
+)
+
+// Estrategia para elegir en qué servidor disponible se juega una partida.
+// Recibe los candidatos ordenados por ID, para que la elección no dependa del
+// orden de recorrido del mapa de servidores. Se llama desde el bucle de eventos.
+type selectorServidor interface {
+	Nombre() string
+	Elegir(candidatos []*GameServerInfo) *GameServerInfo
+}
+
+// Crea la estrategia configurada. La semilla solo se usa en la aleatoria.
+func nuevoSelector(nombre string, semilla int64) (selectorServidor, error) {
+	switch nombre {
+	case seleccionRoundRobin:
+		return &selectorRoundRobin{}, nil
+	case seleccionLRU:
+		return &selectorLRU{usos: make(map[string]uint64)}, nil
+	case seleccionPonderada:
+		return &selectorPonderado{actual: make(map[string]int)}, nil
+	case seleccionAleatoria:
+		return &selectorAleatorio{rnd: rand.New(rand.NewSource(semilla)), semilla: semilla}, nil
+	}
+	return nil, fmt.Errorf("estrategia de selección desconocida: %s (opciones: %s, %s, %s, %s)",
+		nombre, seleccionRoundRobin, seleccionLRU, seleccionPonderada, seleccionAleatoria)
+}
+
+// Recorre los servidores en orden de ID, continuando después del último
+// elegido. Si ese servidor ya no está disponible se sigue con el siguiente.
+type selectorRoundRobin struct {
+	ultimo string
+}
+
+func (r *selectorRoundRobin) Nombre() string { return seleccionRoundRobin }
+
+func (r *selectorRoundRobin) Elegir(candidatos []*GameServerInfo) *GameServerInfo {
+	elegido := candidatos[0]
+	for _, gs := range candidatos {
+		if gs.ID > r.ultimo {
+			elegido = gs
+			break
+		}
+	}
+	r.ultimo = elegido.ID
+	return elegido
+}
+
+// Elige el servidor que hace más tiempo no recibe una partida (los que nunca
+// recibieron una van primero).
+type selectorLRU struct {
+	usos  map[string]uint64 // número de la última elección de cada servidor
+	turno uint64
+}
+
+func (l *selectorLRU) Nombre() string { return seleccionLRU }
+
+func (l *selectorLRU) Elegir(candidatos []*GameServerInfo) *GameServerInfo {
+	elegido := candidatos[0]
+	for _, gs := range candidatos[1:] {
+		if l.usos[gs.ID] < l.usos[elegido.ID] {
+			elegido = gs
+		}
+	}
+	l.turno++
+	l.usos[elegido.ID] = l.turno
+	return elegido
+}
+
+// Round-robin ponderado suave: cada servidor recibe partidas en proporción a
+// su capacidad, intercalando las elecciones en lugar de agruparlas.
+type selectorPonderado struct {
+	actual map[string]int // peso acumulado de cada servidor
+}
+
+func (p *selectorPonderado) Nombre() string { return seleccionPonderada }
+
+func (p *selectorPonderado) Elegir(candidatos []*GameServerInfo) *GameServerInfo {
+	var elegido *GameServerInfo
+	total := 0
+	for _, gs := range candidatos {
+		peso := int(gs.Capacity)
+		total += peso
+		p.actual[gs.ID] += peso
+		if elegido == nil || p.actual[gs.ID] > p.actual[elegido.ID] {
+			elegido = gs
+		}
+	}
+	p.actual[elegido.ID] -= total
+	return elegido
+}
+
+// Elige un servidor al azar con una semilla fija, para poder reproducir la
+// misma secuencia de asignaciones.
+type selectorAleatorio struct {
+	rnd     *rand.Rand
+	semilla int64
+}
+
+func (a *selectorAleatorio) Nombre() string {
+	return fmt.Sprintf("%s (semilla %d)", seleccionAleatoria, a.semilla)
+}
+
+func (a *selectorAleatorio) Elegir(candidatos []*GameServerInfo) *GameServerInfo {
+	return candidatos[a.rnd.Intn(len(candidatos))]
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Elige un servidor disponible y con lease vigente según la estrategia
+// configurada, o nil si no hay ninguno.
+// Debe llamarse desde el bucle de eventos.
+func (s *server) elegirServidor() *GameServerInfo {
+	ahora := time.Now()
+	var candidatos []*GameServerInfo
+	for _, gs := range s.gameServers {
+		if gs.Status == "DISPONIBLE" && s.leaseVigente(gs.ID, ahora) {
+			candidatos = append(candidatos, gs)
+		}
+	}
+	if len(candidatos) == 0 {
+		return nil
+	}
+	sort.Slice(candidatos, func(i, j int) bool { return candidatos[i].ID < candidatos[j].ID })
+	return s.selector.Elegir(candidatos)
+}
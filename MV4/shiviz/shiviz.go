@@ -0,0 +1,250 @@
+// Junta los registros causales de una sesión (los eventos_*.jsonl que
+// escriben el Matchmaker, los servidores de juego y los jugadores) en un log
+// para ShiViz (https://bestchai.bitbucket.io/shiviz/):
+//
+//	go run ./shiviz -salida sesion.log ../eventos_*.jsonl ../MV1/eventos_*.jsonl
+//
+// En ShiViz se pega el contenido de sesion.log y se usa como expresión
+// regular la de su primera línea:
+//
+//	(?<host>\S*) (?<clock>{.*})\n(?<event>.*)
+//
+// ShiViz espera que el componente propio de cada proceso avance de a uno por
+// evento, pero los procesos también avanzan su reloj en pasos que no quedan
+// registrados. Por eso cada componente se renumera según la cantidad de
+// eventos registrados de ese proceso que conoce el evento, lo que mantiene
+// las relaciones causales entre los eventos del log. Además, cada reloj se
+// completa con lo que ya conocían los eventos que conoce: los procesos olvidan
+// los componentes de los servidores que el Matchmaker poda, pero para ShiViz
+// un reloj nunca puede perder lo que sabía.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"MV4/reloj"
+)
+
+// Expresión regular de ShiViz para el formato de salida
+const regexShiViz = `(?<host>\S*) (?<clock>{.*})\n(?<event>.*)`
+
+// ===================== LECTURA =========================
+
+// Lee los eventos de un registro. Avisa si el componente propio de un proceso
+// retrocede: el proceso se reinició y su reloj volvió a empezar, algo que
+// ShiViz no puede mostrar.
+func leer(ruta string) ([]reloj.Evento, error) {
+	f, err := os.Open(ruta)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var eventos []reloj.Evento
+	ultimo := make(map[string]int32)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for linea := 1; sc.Scan(); linea++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		var e reloj.Evento
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", ruta, linea, err)
+		}
+		if e.Reloj[e.Proceso] <= ultimo[e.Proceso] {
+			log.Printf("%s:%d: el reloj de %s retrocede (%d después de %d). ¿Se reinició el proceso durante la sesión?",
+				ruta, linea, e.Proceso, e.Reloj[e.Proceso], ultimo[e.Proceso])
+		}
+		ultimo[e.Proceso] = e.Reloj[e.Proceso]
+		eventos = append(eventos, e)
+	}
+	return eventos, sc.Err()
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Agrupa los eventos por proceso, ordenados por su componente propio. Las
+// réplicas del Matchmaker comparten el proceso "Matchmaker": el líder de
+// turno continúa el reloj del anterior.
+func porProceso(eventos []reloj.Evento) map[string][]reloj.Evento {
+	procesos := make(map[string][]reloj.Evento)
+	for _, e := range eventos {
+		procesos[e.Proceso] = append(procesos[e.Proceso], e)
+	}
+	for p, lista := range procesos {
+		sort.SliceStable(lista, func(i, j int) bool { return lista[i].Reloj[p] < lista[j].Reloj[p] })
+		for i := 1; i < len(lista); i++ {
+			if lista[i].Reloj[p] == lista[i-1].Reloj[p] {
+				log.Printf("Dos eventos de %s con el mismo reloj (%d): %q y %q", p, lista[i].Reloj[p], lista[i-1].Evento, lista[i].Evento)
+			}
+		}
+	}
+	return procesos
+}
+
+// Reloj para ShiViz de un evento: cada componente pasa a ser la cantidad de
+// eventos registrados de ese proceso que el evento conoce. Los procesos sin
+// registro no aparecen. El componente propio es la posición del evento entre
+// los de su proceso.
+func renumerar(e reloj.Evento, posicion int, procesos map[string][]reloj.Evento) map[string]int {
+	vc := map[string]int{e.Proceso: posicion + 1}
+	for p, v := range e.Reloj {
+		lista, ok := procesos[p]
+		if p == e.Proceso || !ok || v <= 0 {
+			continue
+		}
+		conocidos := sort.Search(len(lista), func(i int) bool { return lista[i].Reloj[p] > v })
+		if conocidos > 0 {
+			vc[p] = conocidos
+		}
+	}
+	return vc
+}
+
+// Completa los relojes renumerados hasta que cada uno incluya a los de los
+// eventos que conoce: el anterior de su proceso y el último conocido de cada
+// otro proceso. Así se recupera lo que un proceso olvidó al podar un
+// componente.
+func completar(relojes map[string][]map[string]int) {
+	fusionar := func(vc, otro map[string]int) bool {
+		cambio := false
+		for p, v := range otro {
+			if v > vc[p] {
+				vc[p] = v
+				cambio = true
+			}
+		}
+		return cambio
+	}
+	for cambio := true; cambio; {
+		cambio = false
+		for p, lista := range relojes {
+			for i, vc := range lista {
+				if i > 0 && fusionar(vc, lista[i-1]) {
+					cambio = true
+				}
+				for q, v := range vc {
+					if q != p && v > 0 && v <= len(relojes[q]) && fusionar(vc, relojes[q][v-1]) {
+						cambio = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// Indica si el evento a va antes que b en el log: se ordenan por marca HLC, y
+// los empates, por proceso. Los registros sin marcas HLC se ordenan por hora.
+func anterior(a, b reloj.Evento) bool {
+	if a.HLC != (reloj.Marca{}) && b.HLC != (reloj.Marca{}) {
+		if c := reloj.CompararMarcas(a.HLC, b.HLC); c != 0 {
+			return c < 0
+		}
+		return a.Proceso < b.Proceso
+	}
+	if !a.Hora.Equal(b.Hora) {
+		return a.Hora.Before(b.Hora)
+	}
+	return a.Proceso < b.Proceso
+}
+
+// Escribe los eventos en el formato de ShiViz. Los procesos se intercalan en
+// el orden total de las marcas HLC, que respeta el orden propio de cada uno y
+// el causal entre ellos.
+func escribir(w io.Writer, procesos map[string][]reloj.Evento) error {
+	relojes := make(map[string][]map[string]int)
+	for p, lista := range procesos {
+		for i, e := range lista {
+			relojes[p] = append(relojes[p], renumerar(e, i, procesos))
+		}
+	}
+	completar(relojes)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s\n\n", regexShiViz)
+
+	siguiente := make(map[string]int)
+	for {
+		var elegido string
+		for p, lista := range procesos {
+			i := siguiente[p]
+			if i == len(lista) {
+				continue
+			}
+			if elegido == "" || anterior(lista[i], procesos[elegido][siguiente[elegido]]) {
+				elegido = p
+			}
+		}
+		if elegido == "" {
+			break
+		}
+
+		i := siguiente[elegido]
+		e := procesos[elegido][i]
+		siguiente[elegido]++
+		vc, err := json.Marshal(relojes[elegido][i])
+		if err != nil {
+			return err
+		}
+		descripcion := strings.ReplaceAll(fmt.Sprintf("%s %s", e.Tipo, e.Evento), "\n", " ")
+		if e.HLC != (reloj.Marca{}) {
+			descripcion += " [HLC " + e.HLC.String() + "]"
+		}
+		fmt.Fprintf(bw, "%s %s\n%s\n", e.Proceso, vc, descripcion)
+	}
+	return bw.Flush()
+}
+
+// ===================== MAIN =========================
+
+func main() {
+	salida := flag.String("salida", "", "archivo de salida (por defecto, la salida estándar)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Uso: shiviz [-salida archivo] [registros...]\n"+
+			"Sin registros, se usan los eventos_*.jsonl del directorio actual.\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	rutas := flag.Args()
+	if len(rutas) == 0 {
+		rutas, _ = filepath.Glob("eventos_*.jsonl")
+	}
+	if len(rutas) == 0 {
+		log.Fatal("No hay registros de eventos para juntar")
+	}
+
+	var eventos []reloj.Evento
+	for _, ruta := range rutas {
+		leidos, err := leer(ruta)
+		if err != nil {
+			log.Fatalf("Error al leer %s: %v", ruta, err)
+		}
+		eventos = append(eventos, leidos...)
+	}
+	procesos := porProceso(eventos)
+
+	w := io.Writer(os.Stdout)
+	if *salida != "" {
+		f, err := os.Create(*salida)
+		if err != nil {
+			log.Fatalf("Error al crear %s: %v", *salida, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := escribir(w, procesos); err != nil {
+		log.Fatalf("Error al escribir el log de ShiViz: %v", err)
+	}
+	log.Printf("%d eventos de %d procesos en %d registros", len(eventos), len(procesos), len(rutas))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	pb "MV4/proto/grpc-server/proto"
+	"MV4/reloj"
+)
+
+// Parámetros de la estimación de espera
+const (
+	ventanaThroughput = 10 * time.Minute // solo se consideran las partidas recientes
+	maxMuestras       = 50               // muestras guardadas por modo de juego
+)
+
+// Partida confirmada en un modo: cuántos jugadores salieron de la cola y cuándo.
+type muestraThroughput struct {
+	At      time.Time
+	Players int
+}
+
+// ===================== RPCS =========================
+
+func (s *server) EstimateWaitTime(ctx context.Context, req *pb.WaitTimeRequest) (*pb.WaitTimeResponse, error) {
+	s.registrarEvento(reloj.Recepcion, fmt.Sprintf("EstimateWaitTime del jugador %d", req.PlayerId), req.VectorClock)
+
+	ahora := time.Now()
+	res := &pb.WaitTimeResponse{}
+
+	// Jugadores que deben salir de la cola antes de que le toque al jugador:
+	// los que están por delante más su propia entrada (él y su grupo)
+	var pendientes int
+	mode, enCola := s.playerMode[req.PlayerId]
+	if enCola {
+		for _, entry := range s.playersQueue[mode] {
+			pendientes += len(entry.Players)
+			if contiene(entry.Players, req.PlayerId) {
+				res.TimeInQueue = tiempoEnCola(entry, ahora)
+				res.PlayersAhead = int32(pendientes - len(entry.Players))
+				break
+			}
+		}
+	} else {
+		var ok bool
+		if mode, ok = normalizarModo(req.GameMode); !ok {
+			mode = defaultGameMode
+		}
+		for _, entry := range s.playersQueue[mode] {
+			pendientes += len(entry.Players)
+		}
+		res.PlayersAhead = int32(pendientes)
+		pendientes++
+	}
+	res.GameMode = mode
+
+	res.StatusCode = "UNKNOWN"
+	res.EstimatedWaitSeconds = -1
+	if ritmo := s.ritmoEmparejamiento(mode, ahora); ritmo > 0 {
+		res.StatusCode = "SUCCESS"
+		res.EstimatedWaitSeconds = int32(math.Ceil(float64(pendientes) / ritmo))
+	}
+
+	res.VectorClock = s.relojProto()
+	return res, nil
+}
+
+// =================== FUNCIONES AUXILIARES ====================
+
+// Registra los jugadores que salieron de la cola de un modo al confirmarse
+// una partida. Debe llamarse desde el bucle de eventos.
+func (s *server) registrarThroughput(mode string, players int, at time.Time) {
+	muestras := append(s.throughput[mode], muestraThroughput{At: at, Players: players})
+	if len(muestras) > maxMuestras {
+		muestras = muestras[len(muestras)-maxMuestras:]
+	}
+	s.throughput[mode] = muestras
+	s.marcar("throughput/" + mode)
+}
+
+// Jugadores emparejados por segundo en el modo durante la ventana reciente
+// (o desde que arrancó el matchmaker, si lleva menos tiempo).
+// Debe llamarse desde el bucle de eventos.
+func (s *server) ritmoEmparejamiento(mode string, ahora time.Time) float64 {
+	desde := ahora.Add(-ventanaThroughput)
+	if s.startedAt.After(desde) {
+		desde = s.startedAt
+	}
+
+	total := 0
+	for _, m := range s.throughput[mode] {
+		if m.At.After(desde) {
+			total += m.Players
+		}
+	}
+	duracion := ahora.Sub(desde).Seconds()
+	if total == 0 || duracion <= 0 {
+		return 0
+	}
+	return float64(total) / duracion
+}
+
+// Tiempo que lleva una entrada en la cola, redondeado a segundos.
+func tiempoEnCola(entry *queueEntry, ahora time.Time) string {
+	return ahora.Sub(entry.EnqueuedAt).Round(time.Second).String()
+}